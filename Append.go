@@ -0,0 +1,269 @@
+// Reflection Free Encoding
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// hexDigits are the digits of the \u escapes of JSON strings
+const hexDigits string = "0123456789abcdef"
+
+// appendFieldText appends the text form of a field value to the line buffer
+// Strings, integers, floats, booleans and durations are appended without reflection or allocation
+func appendFieldText(lineBuffer []byte, logField Field) []byte {
+	switch logField.valueType {
+	case fieldString:
+		return append(lineBuffer, logField.stringValue...)
+
+	case fieldInteger:
+		return strconv.AppendInt(lineBuffer, logField.integerValue, 10)
+
+	case fieldFloat:
+		return appendFloatText(lineBuffer, math.Float64frombits(uint64(logField.integerValue)))
+
+	case fieldBoolean:
+		return strconv.AppendBool(lineBuffer, logField.integerValue == 1)
+
+	case fieldDuration:
+		return appendDuration(lineBuffer, time.Duration(logField.integerValue))
+
+	case fieldError:
+		if logField.interfaceValue == nil {
+			return append(lineBuffer, "<nil>"...)
+		}
+
+		return append(lineBuffer, logField.interfaceValue.(error).Error()...)
+	}
+
+	return append(lineBuffer, formatFieldValue(logField.interfaceValue)...)
+}
+
+// appendFloatText appends the floating point value as the %v verb of the fmt package formats it
+func appendFloatText(lineBuffer []byte, floatValue float64) []byte {
+	if math.IsInf(floatValue, 0) {
+		if floatValue > 0 {
+			return append(lineBuffer, "+Inf"...)
+		}
+
+		return append(lineBuffer, "-Inf"...)
+	}
+
+	return strconv.AppendFloat(lineBuffer, floatValue, 'g', -1, 64)
+}
+
+// appendJSONString appends the text as a JSON string, escaped as encoding/json escapes it
+// Control characters, the HTML characters <, > and &, the line and paragraph separators and
+// invalid UTF-8 are escaped so the output is identical to json.Marshal
+func appendJSONString(jsonBuffer []byte, stringValue string) []byte {
+	jsonBuffer = append(jsonBuffer, '"')
+	plainStart := 0
+
+	for byteIndex := 0; byteIndex < len(stringValue); {
+		if currentByte := stringValue[byteIndex]; currentByte < utf8.RuneSelf {
+			if currentByte >= 0x20 && currentByte != '"' && currentByte != '\\' &&
+				currentByte != '<' && currentByte != '>' && currentByte != '&' {
+				byteIndex++
+				continue
+			}
+
+			jsonBuffer = append(jsonBuffer, stringValue[plainStart:byteIndex]...)
+
+			switch currentByte {
+			case '"', '\\':
+				jsonBuffer = append(jsonBuffer, '\\', currentByte)
+
+			case '\b':
+				jsonBuffer = append(jsonBuffer, '\\', 'b')
+
+			case '\f':
+				jsonBuffer = append(jsonBuffer, '\\', 'f')
+
+			case '\n':
+				jsonBuffer = append(jsonBuffer, '\\', 'n')
+
+			case '\r':
+				jsonBuffer = append(jsonBuffer, '\\', 'r')
+
+			case '\t':
+				jsonBuffer = append(jsonBuffer, '\\', 't')
+
+			default:
+				jsonBuffer = append(jsonBuffer, '\\', 'u', '0', '0', hexDigits[currentByte>>4], hexDigits[currentByte&0xF])
+			}
+
+			byteIndex++
+			plainStart = byteIndex
+
+			continue
+		}
+
+		decodedRune, runeSize := utf8.DecodeRuneInString(stringValue[byteIndex:])
+
+		if decodedRune == utf8.RuneError && runeSize == 1 {
+			jsonBuffer = append(append(jsonBuffer, stringValue[plainStart:byteIndex]...), "\ufffd"...)
+			byteIndex += runeSize
+			plainStart = byteIndex
+
+			continue
+		}
+
+		if decodedRune == '\u2028' || decodedRune == '\u2029' {
+			jsonBuffer = append(append(jsonBuffer, stringValue[plainStart:byteIndex]...), '\\', 'u', '2', '0', '2',
+				hexDigits[decodedRune&0xF])
+			byteIndex += runeSize
+			plainStart = byteIndex
+
+			continue
+		}
+
+		byteIndex += runeSize
+	}
+
+	return append(append(jsonBuffer, stringValue[plainStart:]...), '"')
+}
+
+// appendJSONFloat appends the finite floating point value as encoding/json encodes it
+func appendJSONFloat(jsonBuffer []byte, floatValue float64) []byte {
+	floatFormat := byte('f')
+
+	if absoluteValue := math.Abs(floatValue); absoluteValue != 0 && (absoluteValue < 1e-6 || absoluteValue >= 1e21) {
+		floatFormat = 'e'
+	}
+
+	jsonBuffer = strconv.AppendFloat(jsonBuffer, floatValue, floatFormat, -1, 64)
+
+	// Shorten an exponent such as e-07 to e-7
+
+	if bufferLength := len(jsonBuffer); floatFormat == 'e' && bufferLength >= 4 && jsonBuffer[bufferLength-4] == 'e' &&
+		jsonBuffer[bufferLength-3] == '-' && jsonBuffer[bufferLength-2] == '0' {
+		jsonBuffer[bufferLength-2] = jsonBuffer[bufferLength-1]
+		jsonBuffer = jsonBuffer[:bufferLength-1]
+	}
+
+	return jsonBuffer
+}
+
+// appendDuration appends the duration in the form of time.Duration.String, such as 1h2m0.5s or 1.5ms
+func appendDuration(lineBuffer []byte, durationValue time.Duration) []byte {
+	var durationText [32]byte
+
+	textStart := len(durationText)
+	remainingValue := uint64(durationValue)
+
+	if durationValue < 0 {
+		remainingValue = -remainingValue
+	}
+
+	if remainingValue < uint64(time.Second) {
+		// Durations below a second use a smaller unit, such as 1.2ms
+
+		var fractionDigits int
+
+		textStart--
+		durationText[textStart] = 's'
+		textStart--
+
+		switch {
+		case remainingValue == 0:
+			return append(lineBuffer, "0s"...)
+
+		case remainingValue < uint64(time.Microsecond):
+			durationText[textStart] = 'n'
+
+		case remainingValue < uint64(time.Millisecond):
+			fractionDigits = 3
+			textStart--
+			copy(durationText[textStart:], "µ")
+
+		default:
+			fractionDigits = 6
+			durationText[textStart] = 'm'
+		}
+
+		textStart, remainingValue = formatFraction(durationText[:textStart], remainingValue, fractionDigits)
+		textStart = formatInteger(durationText[:textStart], remainingValue)
+	} else {
+		textStart--
+		durationText[textStart] = 's'
+
+		textStart, remainingValue = formatFraction(durationText[:textStart], remainingValue, 9)
+		textStart = formatInteger(durationText[:textStart], remainingValue%60)
+		remainingValue /= 60
+
+		if remainingValue > 0 {
+			textStart--
+			durationText[textStart] = 'm'
+			textStart = formatInteger(durationText[:textStart], remainingValue%60)
+			remainingValue /= 60
+
+			if remainingValue > 0 {
+				textStart--
+				durationText[textStart] = 'h'
+				textStart = formatInteger(durationText[:textStart], remainingValue)
+			}
+		}
+	}
+
+	if durationValue < 0 {
+		textStart--
+		durationText[textStart] = '-'
+	}
+
+	return append(lineBuffer, durationText[textStart:]...)
+}
+
+// formatFraction writes the fraction of the value with the number of digits at the end of the text,
+// without trailing zeros, and returns the start of the written text along with the integer part
+func formatFraction(durationText []byte, durationValue uint64, fractionDigits int) (int, uint64) {
+	textStart := len(durationText)
+	printDigits := false
+
+	for digitIndex := 0; digitIndex < fractionDigits; digitIndex++ {
+		fractionDigit := durationValue % 10
+		printDigits = printDigits || fractionDigit != 0
+
+		if printDigits {
+			textStart--
+			durationText[textStart] = byte(fractionDigit) + '0'
+		}
+
+		durationValue /= 10
+	}
+
+	if printDigits {
+		textStart--
+		durationText[textStart] = '.'
+	}
+
+	return textStart, durationValue
+}
+
+// formatInteger writes the value in decimal at the end of the text and returns the start of the written text
+func formatInteger(durationText []byte, durationValue uint64) int {
+	textStart := len(durationText)
+
+	if durationValue == 0 {
+		textStart--
+		durationText[textStart] = '0'
+
+		return textStart
+	}
+
+	for durationValue > 0 {
+		textStart--
+		durationText[textStart] = byte(durationValue%10) + '0'
+		durationValue /= 10
+	}
+
+	return textStart
+}
@@ -0,0 +1,83 @@
+// Reflection Free Encoding Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// typedFieldEntry is a log message holding one field of every type encoded without allocation
+var typedFieldEntry = logEntry{
+	messageType:  MessageNormal,
+	messageLevel: lookupLevel(MessageNormal),
+	messageParts: []interface{}{"Sample typed field log message"},
+	entryFields: []Field{
+		String("key_01", "a <b>"),
+		Int("key_02", 123456),
+		Bool("key_03", true),
+		Float64("key_04", 1.5),
+		Duration("key_05", 1500*time.Millisecond),
+		Err(errors.New("sample error")),
+	},
+}
+
+func TestTypedFieldAllocations(t *testing.T) {
+	lineBuffer := acquireBuffer()
+	defer releaseBuffer(lineBuffer)
+
+	jsonAllocations := testing.AllocsPerRun(100, func() {
+		*lineBuffer = (*lineBuffer)[:0]
+
+		for _, entryField := range typedFieldEntry.entryFields {
+			*lineBuffer = appendJSONField(*lineBuffer, entryField.Key, entryField)
+		}
+	})
+
+	if jsonAllocations > 0 {
+		t.Errorf("JSON encoding of typed fields made %v allocations, want 0", jsonAllocations)
+	}
+
+	textAllocations := testing.AllocsPerRun(100, func() {
+		*lineBuffer = appendEntryText((*lineBuffer)[:0], typedFieldEntry)
+	})
+
+	if textAllocations > 0 {
+		t.Errorf("text encoding of an entry with typed fields made %v allocations, want 0", textAllocations)
+	}
+}
+
+func TestLogCallAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates on its own")
+	}
+
+	logInstance := InitializeWriter(io.Discard, WithFile(true), WithTerminal(false))
+
+	// The message content is collected in one slice, the typed fields in another and the call
+	// itself boxes every typed field into the message content
+
+	plainAllocations := testing.AllocsPerRun(100, func() {
+		logInstance.FLog(nil, "Sample plain log message")
+	})
+
+	if plainAllocations > 1 {
+		t.Errorf("log call without fields made %v allocations, want at most 1", plainAllocations)
+	}
+
+	fieldAllocations := testing.AllocsPerRun(100, func() {
+		logInstance.FLog(nil, "Sample typed field log message", String("key_01", "a b"), Int("key_02", 1))
+	})
+
+	if fieldAllocations > 4 {
+		t.Errorf("log call with two typed fields made %v allocations, want at most 4", fieldAllocations)
+	}
+}
@@ -0,0 +1,264 @@
+// Asynchronous Logging
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy selects what a full asynchronous queue does with a new log message
+type OverflowPolicy int
+
+const (
+	OverflowBlock      OverflowPolicy = iota // OverflowBlock makes the log call wait until the queue has room
+	OverflowDropOldest                       // OverflowDropOldest discards the oldest queued message to make room
+	OverflowDropNewest                       // OverflowDropNewest discards the new message
+)
+
+// queuedTask is an output task waiting in the queue
+type queuedTask struct {
+	taskFunction func() // taskFunction writes the output
+	entryCount   int    // entryCount is the number of messages the task writes, zero for tasks that are never dropped
+}
+
+// taskQueue runs the output of log messages on a background goroutine
+// Tasks run in the order they were submitted and never take the log instance lock
+type taskQueue struct {
+	queueLock   sync.Mutex   // queueLock guards the queued tasks and the closed state
+	taskReady   sync.Cond    // taskReady wakes the background goroutine when a task is queued or the queue closes
+	spaceReady  sync.Cond    // spaceReady wakes a waiting submission when a task is taken or the queue closes
+	queuedTasks []queuedTask // queuedTasks is the ring of tasks waiting to run
+	taskStart   int          // taskStart is the position of the oldest queued task
+	taskCount   int          // taskCount is the number of queued tasks
+	queueClosed bool         // queueClosed reports whether the queue stopped accepting tasks
+
+	overflowPolicy OverflowPolicy // overflowPolicy selects what a full queue does with a new message
+	droppedEntries *atomic.Uint64 // droppedEntries counts the messages discarded by the overflow policy
+	droppedSince   uint64         // droppedSince counts the messages discarded since the queue was last reported full
+	doneChannel    chan struct{}  // doneChannel is closed once the background goroutine returns
+
+	reportDiagnostic func(diagnosticComponent string, diagnosticMessage string, diagnosticError error) // reportDiagnostic reports dropped messages
+}
+
+// WithAsync writes log messages from a background goroutine
+// Log calls push their output onto a queue holding up to queueSize messages
+// Flush and Close wait until every queued message is written
+func WithAsync(queueSize int) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.asyncSize = queueSize
+	}
+}
+
+// WithOverflowPolicy selects what a full asynchronous queue does with a new log message
+// OverflowBlock, the default, never loses a message but makes the log call wait, the drop policies keep
+// the log call fast and count every discarded message in DroppedEntries, and report a diagnostic when
+// the queue starts dropping along with the number of dropped messages once it has room again
+// Fatal and panic messages and the tasks of Flush and Close are never dropped
+func WithOverflowPolicy(overflowPolicy OverflowPolicy) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.overflowPolicy = overflowPolicy
+	}
+}
+
+// DroppedEntries returns the number of messages the overflow policy discarded since the log instance was created
+func (logInstance *LogInstance) DroppedEntries() uint64 {
+	return logInstance.droppedEntries.Load()
+}
+
+// startQueue starts the background goroutine when asynchronous logging is enabled
+func (logInstance *LogInstance) startQueue() {
+	if logInstance.asyncSize <= 0 {
+		return
+	}
+
+	logQueue := &taskQueue{
+		queuedTasks:    make([]queuedTask, logInstance.asyncSize),
+		overflowPolicy: logInstance.overflowPolicy,
+		droppedEntries: logInstance.droppedEntries,
+		doneChannel:    make(chan struct{}),
+
+		reportDiagnostic: logInstance.diagnose,
+	}
+
+	logQueue.taskReady.L = &logQueue.queueLock
+	logQueue.spaceReady.L = &logQueue.queueLock
+
+	go logQueue.run()
+
+	logInstance.logOutput.logQueue = logQueue
+}
+
+// run runs the queued tasks until the queue is closed and empty
+func (logQueue *taskQueue) run() {
+	defer close(logQueue.doneChannel)
+
+	for {
+		logQueue.queueLock.Lock()
+
+		for logQueue.taskCount == 0 && !logQueue.queueClosed {
+			logQueue.taskReady.Wait()
+		}
+
+		if logQueue.taskCount == 0 {
+			logQueue.queueLock.Unlock()
+			return
+		}
+
+		nextTask := logQueue.queuedTasks[logQueue.taskStart]
+		logQueue.queuedTasks[logQueue.taskStart] = queuedTask{}
+		logQueue.taskStart = (logQueue.taskStart + 1) % len(logQueue.queuedTasks)
+		logQueue.taskCount--
+
+		logQueue.spaceReady.Signal()
+		logQueue.queueLock.Unlock()
+
+		nextTask.taskFunction()
+	}
+}
+
+// submit queues the task and reports whether the queue accepted it
+// A full queue applies the overflow policy to tasks writing messages, every other task waits for room
+func (logQueue *taskQueue) submit(submittedTask queuedTask) bool {
+	logQueue.queueLock.Lock()
+	defer logQueue.queueLock.Unlock()
+
+	taskDropped := false
+
+	for !logQueue.queueClosed && logQueue.taskCount == len(logQueue.queuedTasks) {
+		if submittedTask.entryCount > 0 && logQueue.overflowPolicy == OverflowDropNewest {
+			logQueue.countDropped(submittedTask.entryCount)
+			return true
+		}
+
+		if submittedTask.entryCount > 0 && logQueue.overflowPolicy == OverflowDropOldest && logQueue.dropOldest() {
+			taskDropped = true
+			break
+		}
+
+		logQueue.spaceReady.Wait()
+	}
+
+	if logQueue.queueClosed {
+		return false
+	}
+
+	logQueue.queuedTasks[(logQueue.taskStart+logQueue.taskCount)%len(logQueue.queuedTasks)] = submittedTask
+	logQueue.taskCount++
+	logQueue.taskReady.Signal()
+
+	// Report the dropped messages once the queue has room again
+
+	if !taskDropped && submittedTask.entryCount > 0 && logQueue.droppedSince > 0 {
+		logQueue.reportDiagnostic(DiagnosticQueue, fmt.Sprintf("dropped %d messages while the asynchronous queue was full",
+			logQueue.droppedSince), nil)
+		logQueue.droppedSince = 0
+	}
+
+	return true
+}
+
+// dropOldest discards the oldest queued task writing messages and reports whether there was one
+// The queue lock must be held by the caller
+func (logQueue *taskQueue) dropOldest() bool {
+	queueCapacity := len(logQueue.queuedTasks)
+
+	for taskIndex := 0; taskIndex < logQueue.taskCount; taskIndex++ {
+		droppedTask := logQueue.queuedTasks[(logQueue.taskStart+taskIndex)%queueCapacity]
+
+		if droppedTask.entryCount == 0 {
+			continue
+		}
+
+		// Move the older tasks that are never dropped up by one position
+
+		for shiftIndex := taskIndex; shiftIndex > 0; shiftIndex-- {
+			logQueue.queuedTasks[(logQueue.taskStart+shiftIndex)%queueCapacity] =
+				logQueue.queuedTasks[(logQueue.taskStart+shiftIndex-1)%queueCapacity]
+		}
+
+		logQueue.queuedTasks[logQueue.taskStart] = queuedTask{}
+		logQueue.taskStart = (logQueue.taskStart + 1) % queueCapacity
+		logQueue.taskCount--
+		logQueue.countDropped(droppedTask.entryCount)
+
+		return true
+	}
+
+	return false
+}
+
+// countDropped counts the discarded messages and reports when the queue starts dropping
+// The queue lock must be held by the caller
+func (logQueue *taskQueue) countDropped(entryCount int) {
+	if logQueue.droppedSince == 0 {
+		logQueue.reportDiagnostic(DiagnosticQueue, "asynchronous queue is full, messages are dropped", nil)
+	}
+
+	logQueue.droppedSince += uint64(entryCount)
+	logQueue.droppedEntries.Add(uint64(entryCount))
+}
+
+// stop stops accepting tasks and waits for the queued tasks to finish
+func (logQueue *taskQueue) stop() {
+	logQueue.queueLock.Lock()
+
+	if !logQueue.queueClosed {
+		logQueue.queueClosed = true
+		logQueue.taskReady.Broadcast()
+		logQueue.spaceReady.Broadcast()
+	}
+
+	logQueue.queueLock.Unlock()
+
+	<-logQueue.doneChannel
+}
+
+// runTask runs the output task writing entryCount messages in the background when asynchronous logging is enabled
+// Tasks submitted after the queue was closed are dropped
+func (logInstance *LogInstance) runTask(taskFunction func(), entryCount int) {
+	if logInstance.logOutput.logQueue == nil {
+		taskFunction()
+		return
+	}
+
+	logInstance.logOutput.logQueue.submit(queuedTask{taskFunction: taskFunction, entryCount: entryCount})
+}
+
+// runTaskWait runs the task after every queued task and waits for it to finish
+// When the queue was closed, the task runs on the calling goroutine
+func (logInstance *LogInstance) runTaskWait(taskFunction func()) {
+	if logInstance.logOutput.logQueue == nil {
+		taskFunction()
+		return
+	}
+
+	taskDone := make(chan struct{})
+
+	if !logInstance.logOutput.logQueue.submit(queuedTask{taskFunction: func() {
+		defer close(taskDone)
+		taskFunction()
+	}}) {
+		taskFunction()
+		return
+	}
+
+	<-taskDone
+}
+
+// detachContent renders the message content immediately for asynchronous output
+// Values changed by the caller after the log call do not alter the queued message
+func (logInstance *LogInstance) detachContent(messageContent []interface{}) []interface{} {
+	if logInstance.logOutput.logQueue == nil || len(messageContent) == 0 {
+		return messageContent
+	}
+
+	return []interface{}{fmt.Sprint(messageContent...)}
+}
@@ -0,0 +1,131 @@
+// Audit Trail
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Reserved keys of the audit events
+const (
+	auditKeyActor    string = "actor"    // auditKeyActor is the key holding who performed the action
+	auditKeyAction   string = "action"   // auditKeyAction is the key holding what was performed
+	auditKeyResource string = "resource" // auditKeyResource is the key holding what the action was performed on
+	auditKeyOutcome  string = "outcome"  // auditKeyOutcome is the key holding the result of the action
+)
+
+// errNoAudit is returned when an audit event is written by a log instance without an audit trail
+var errNoAudit = errors.New("log instance has no audit trail")
+
+// auditTrail is the append-only file receiving the audit events of a log instance and its children
+type auditTrail struct {
+	auditLock   sync.Mutex // auditLock serializes the audit events
+	auditPath   string     // auditPath is the file holding the audit events
+	auditWriter io.Writer  // auditWriter receives the audit events, nil until the first event
+}
+
+// WithAuditTrail writes the events of Audit to the file at auditPath, apart from the application log
+// The file is opened on the first event and only ever appended to, it is never truncated or rotated
+// With WithHashChain, the audit events are chained to each other as well
+func WithAuditTrail(auditPath string) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logAudit = &auditTrail{auditPath: auditPath}
+	}
+}
+
+// Audit writes a security or compliance event to the audit trail and commits it to disk before returning
+// The actor, action, resource and outcome are mandatory and written next to the time, the bound fields and auditFields
+// Audit events ignore the level and are never sampled, rate limited, collapsed, filtered, redacted or handed to hooks
+func (logInstance *LogInstance) Audit(auditActor string, auditAction string, auditResource string,
+	auditOutcome string, auditFields ...Field) error {
+	if logInstance.logAudit == nil {
+		return errNoAudit
+	}
+
+	if logInstance.logStopped.Load() {
+		return errShutdown
+	}
+
+	mandatoryFields := []Field{
+		String(auditKeyActor, auditActor),
+		String(auditKeyAction, auditAction),
+		String(auditKeyResource, auditResource),
+		String(auditKeyOutcome, auditOutcome),
+	}
+
+	for _, mandatoryField := range mandatoryFields {
+		if mandatoryField.stringValue == "" {
+			return fmt.Errorf("the audit event has no %s", mandatoryField.Key)
+		}
+	}
+
+	logAudit := logInstance.logAudit
+
+	logAudit.auditLock.Lock()
+	defer logAudit.auditLock.Unlock()
+
+	if logAudit.auditWriter == nil {
+		auditFile, openError := openFile(logAudit.auditPath, true)
+
+		if openError != nil {
+			return openError
+		}
+
+		logAudit.auditWriter = logInstance.chainOutput(auditFile, lastChainHash(logAudit.auditPath))
+	}
+
+	auditBuffer := []byte{'{'}
+	auditBuffer = appendJSONField(auditBuffer, jsonKeyTime, jsonTime(logInstance, logInstance.logClock()))
+
+	for _, mandatoryField := range mandatoryFields {
+		auditBuffer = append(auditBuffer, ',')
+		auditBuffer = appendJSONField(auditBuffer, mandatoryField.Key, mandatoryField)
+	}
+
+	eventFields, _ := collectFields(logInstance.boundFields, nil, nil)
+
+	eventFields = append(eventFields, auditFields...)
+	evaluateLazy(eventFields, nil)
+
+	for _, eventField := range eventFields {
+		fieldKey := eventField.Key
+
+		switch fieldKey {
+		case jsonKeyTime, auditKeyActor, auditKeyAction, auditKeyResource, auditKeyOutcome:
+			fieldKey = jsonKeyFields + fieldKey
+		}
+
+		auditBuffer = append(auditBuffer, ',')
+		auditBuffer = appendJSONField(auditBuffer, fieldKey, eventField)
+	}
+
+	if _, writeError := logAudit.auditWriter.Write(append(auditBuffer, '}', '\n')); writeError != nil {
+		return writeError
+	}
+
+	return flushOutput(logAudit.auditWriter)
+}
+
+// close closes the audit file, later events open it again
+func (logAudit *auditTrail) close() error {
+	logAudit.auditLock.Lock()
+	defer logAudit.auditLock.Unlock()
+
+	if logAudit.auditWriter == nil {
+		return nil
+	}
+
+	auditCloser, _ := logAudit.auditWriter.(io.Closer)
+	logAudit.auditWriter = nil
+
+	return auditCloser.Close()
+}
@@ -0,0 +1,193 @@
+// Batched Output Delivery
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultBatchSize     int           = 100             // defaultBatchSize is the default number of messages sent together
+	defaultBatchInterval time.Duration = 5 * time.Second // defaultBatchInterval is the default period between two deliveries
+	defaultRetryDelay    time.Duration = time.Second     // defaultRetryDelay is the default delay before the first retry
+)
+
+// BatchSettings configures the batched delivery of log messages
+// Zero values select the defaults
+type BatchSettings struct {
+	BatchSize     int           // BatchSize is the number of messages delivered together, 100 by default
+	FlushInterval time.Duration // FlushInterval is the period between two deliveries, 5 seconds by default
+	MaxRetries    int           // MaxRetries is the number of retries after a failed delivery
+	RetryDelay    time.Duration // RetryDelay is the delay before the first retry, doubled on every retry, 1 second by default
+}
+
+// batchSender collects encoded log messages and delivers them in batches from a background goroutine
+// A batch is delivered once it is full, every batch interval, on Flush and on Close
+type batchSender struct {
+	batchLock      sync.Mutex                           // batchLock guards the pending messages
+	sendLock       sync.Mutex                           // sendLock serializes the deliveries
+	reportInstance atomic.Pointer[LogInstance]          // reportInstance is the log instance that last queued a message, told about failed deliveries
+	pendingItems   []interface{}                        // pendingItems are the encoded messages not yet delivered
+	batchSize      int                                  // batchSize is the number of messages that triggers a delivery
+	maximumRetries int                                  // maximumRetries is the number of retries after a failed delivery
+	retryDelay     time.Duration                        // retryDelay is the delay before the first retry, doubled on every retry
+	deliverBatch   func(batchItems []interface{}) error // deliverBatch sends a batch, failures wrapped in retryableError are retried
+	fullChannel    chan struct{}                        // fullChannel wakes the background goroutine once a batch is full
+	stopChannel    chan struct{}                        // stopChannel stops the background goroutine
+	doneChannel    chan struct{}                        // doneChannel is closed once the background goroutine returned
+	stopOnce       sync.Once                            // stopOnce stops the background goroutine a single time
+}
+
+// retryableError marks a delivery failure that may succeed when retried
+type retryableError struct {
+	deliveryError error // deliveryError is the cause of the failure
+}
+
+// Error returns the message of the underlying failure
+func (deliveryFailure retryableError) Error() string {
+	return deliveryFailure.deliveryError.Error()
+}
+
+// newBatchSender starts delivering batches with the settings and the delivery function
+func newBatchSender(batchSettings BatchSettings, deliverBatch func(batchItems []interface{}) error) *batchSender {
+	logBatch := &batchSender{
+		batchSize:      batchSettings.BatchSize,
+		maximumRetries: batchSettings.MaxRetries,
+		retryDelay:     batchSettings.RetryDelay,
+		deliverBatch:   deliverBatch,
+		fullChannel:    make(chan struct{}, 1),
+		stopChannel:    make(chan struct{}),
+		doneChannel:    make(chan struct{}),
+	}
+
+	if logBatch.batchSize <= 0 {
+		logBatch.batchSize = defaultBatchSize
+	}
+
+	if logBatch.retryDelay <= 0 {
+		logBatch.retryDelay = defaultRetryDelay
+	}
+
+	batchInterval := batchSettings.FlushInterval
+
+	if batchInterval <= 0 {
+		batchInterval = defaultBatchInterval
+	}
+
+	go logBatch.deliverPeriodically(batchInterval)
+
+	return logBatch
+}
+
+// add queues an encoded message of the log instance and wakes the background goroutine once the batch is full
+func (logBatch *batchSender) add(logInstance *LogInstance, batchItem interface{}) {
+	logBatch.reportInstance.Store(logInstance)
+
+	logBatch.batchLock.Lock()
+	logBatch.pendingItems = append(logBatch.pendingItems, batchItem)
+	batchFull := len(logBatch.pendingItems) >= logBatch.batchSize
+	logBatch.batchLock.Unlock()
+
+	if batchFull {
+		select {
+		case logBatch.fullChannel <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// deliverPeriodically delivers the pending messages on every tick and whenever a batch is full
+func (logBatch *batchSender) deliverPeriodically(batchInterval time.Duration) {
+	defer close(logBatch.doneChannel)
+
+	batchTicker := time.NewTicker(batchInterval)
+	defer batchTicker.Stop()
+
+	for {
+		select {
+		case <-batchTicker.C:
+			logBatch.deliverPending()
+
+		case <-logBatch.fullChannel:
+			logBatch.deliverPending()
+
+		case <-logBatch.stopChannel:
+			return
+		}
+	}
+}
+
+// deliverPending delivers every pending message without a caller waiting for the result, so a failed
+// delivery is reported as a diagnostic of the log instance that queued the messages
+func (logBatch *batchSender) deliverPending() {
+	if deliveryError := logBatch.Flush(); deliveryError != nil {
+		logBatch.reportInstance.Load().diagnose(DiagnosticDelivery, "unable to deliver a batch of log messages", deliveryError)
+	}
+}
+
+// Flush delivers every pending message, one batch at a time
+func (logBatch *batchSender) Flush() error {
+	logBatch.sendLock.Lock()
+	defer logBatch.sendLock.Unlock()
+
+	for {
+		logBatch.batchLock.Lock()
+		batchLength := len(logBatch.pendingItems)
+
+		if batchLength > logBatch.batchSize {
+			batchLength = logBatch.batchSize
+		}
+
+		batchItems := logBatch.pendingItems[:batchLength:batchLength]
+		logBatch.pendingItems = logBatch.pendingItems[batchLength:]
+		logBatch.batchLock.Unlock()
+
+		if batchLength == 0 {
+			return nil
+		}
+
+		if deliveryError := logBatch.deliver(batchItems); deliveryError != nil {
+			return deliveryError
+		}
+	}
+}
+
+// Close stops the background goroutine and delivers every pending message
+func (logBatch *batchSender) Close() error {
+	logBatch.stopOnce.Do(func() {
+		close(logBatch.stopChannel)
+	})
+
+	<-logBatch.doneChannel
+
+	return logBatch.Flush()
+}
+
+// deliver sends the batch, retrying retryable failures with an exponential backoff
+// The batch is dropped once every retry failed
+func (logBatch *batchSender) deliver(batchItems []interface{}) error {
+	retryDelay := logBatch.retryDelay
+
+	for retryCount := 0; ; retryCount++ {
+		deliveryError := logBatch.deliverBatch(batchItems)
+
+		if deliveryError == nil {
+			return nil
+		}
+
+		if _, isRetryable := deliveryError.(retryableError); !isRetryable || retryCount >= logBatch.maximumRetries {
+			return deliveryError
+		}
+
+		time.Sleep(retryDelay)
+		retryDelay *= 2
+	}
+}
@@ -0,0 +1,47 @@
+// Batched Output Delivery Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBackgroundDeliveryReportsFailures(t *testing.T) {
+	failingServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, _ *http.Request) {
+		responseWriter.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failingServer.Close()
+
+	reportedDiagnostics := make(chan Diagnostic, 1)
+	httpDestination := NewHTTPDestination(failingServer.URL, HTTPSettings{BatchSettings: BatchSettings{BatchSize: 1}})
+	defer httpDestination.Close()
+
+	logInstance := InitializeWriter(nil, WithFile(false), WithTerminal(false), WithDestination(httpDestination),
+		WithDiagnostics(func(logDiagnostic Diagnostic) {
+			select {
+			case reportedDiagnostics <- logDiagnostic:
+			default:
+			}
+		}))
+
+	logInstance.FLog(nil, "undelivered message")
+
+	select {
+	case logDiagnostic := <-reportedDiagnostics:
+		if logDiagnostic.Component != DiagnosticDelivery || logDiagnostic.Error == nil {
+			t.Errorf("diagnostic %+v, want a %s diagnostic with the delivery error", logDiagnostic, DiagnosticDelivery)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed background delivery was not reported")
+	}
+}
@@ -0,0 +1,209 @@
+// Buffered File Output
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// bufferWriter buffers the file output and flushes it periodically
+// It forwards rotation and reopening to the wrapped writer after flushing
+type bufferWriter struct {
+	bufferLock   sync.Mutex    // bufferLock guards the buffer against the periodic flush
+	outputBuffer *bufio.Writer // outputBuffer holds the output not yet written
+	targetWriter io.Writer     // targetWriter receives the flushed output
+	stopChannel  chan struct{} // stopChannel stops the periodic flush
+	stopOnce     sync.Once     // stopOnce closes the stop channel a single time
+}
+
+// WithBuffer buffers the file output in memory to reduce the number of writes
+// The buffer holds bufferSize bytes and is flushed every flushInterval, on Fatal, Flush and Close
+// A flushInterval of zero disables the periodic flush
+func WithBuffer(bufferSize int, flushInterval time.Duration) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.bufferSize = bufferSize
+		logInstance.flushInterval = flushInterval
+	}
+}
+
+// bufferOutput wraps the file output in a buffer when buffering is enabled
+func (logInstance *LogInstance) bufferOutput(logWriter io.Writer) io.Writer {
+	if logInstance.bufferSize <= 0 || logWriter == io.Discard {
+		return logWriter
+	}
+
+	logBuffer := &bufferWriter{
+		outputBuffer: bufio.NewWriterSize(logWriter, logInstance.bufferSize),
+		targetWriter: logWriter,
+		stopChannel:  make(chan struct{}),
+	}
+
+	if logInstance.flushInterval > 0 {
+		go logBuffer.flushPeriodically(logInstance.flushInterval)
+	}
+
+	return logBuffer
+}
+
+// flushPeriodically flushes the buffer on every tick until the buffer is closed
+func (logBuffer *bufferWriter) flushPeriodically(flushInterval time.Duration) {
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+
+	for {
+		select {
+		case <-flushTicker.C:
+			logBuffer.Flush()
+
+		case <-logBuffer.stopChannel:
+			return
+		}
+	}
+}
+
+// Write writes to the buffer
+// Content that does not fit flushes the buffer first, so every write of the wrapped writer ends on a
+// complete line and a line larger than the buffer is written directly in a single call
+func (logBuffer *bufferWriter) Write(writeContent []byte) (int, error) {
+	logBuffer.bufferLock.Lock()
+	defer logBuffer.bufferLock.Unlock()
+
+	if len(writeContent) > logBuffer.outputBuffer.Available() && logBuffer.outputBuffer.Buffered() > 0 {
+		if flushError := logBuffer.flushBuffer(); flushError != nil {
+			return 0, flushError
+		}
+	}
+
+	writtenSize, writeError := logBuffer.outputBuffer.Write(writeContent)
+
+	if writeError != nil {
+		logBuffer.outputBuffer.Reset(logBuffer.targetWriter)
+	}
+
+	return writtenSize, writeError
+}
+
+// Flush writes the buffered output to the wrapped writer
+func (logBuffer *bufferWriter) Flush() error {
+	logBuffer.bufferLock.Lock()
+	defer logBuffer.bufferLock.Unlock()
+
+	return logBuffer.flushBuffer()
+}
+
+// flushBuffer writes the buffered output to the wrapped writer
+// The errors of a bufio.Writer are sticky, so after a failed write the pending output is dropped and the
+// buffer is reset, letting later messages reach the wrapped writer once it recovers
+// The buffer lock must be held by the caller
+func (logBuffer *bufferWriter) flushBuffer() error {
+	flushError := logBuffer.outputBuffer.Flush()
+
+	if flushError != nil {
+		logBuffer.outputBuffer.Reset(logBuffer.targetWriter)
+	}
+
+	return flushError
+}
+
+// Sync flushes the buffer and commits the wrapped writer to stable storage
+func (logBuffer *bufferWriter) Sync() error {
+	if flushError := logBuffer.Flush(); flushError != nil {
+		return flushError
+	}
+
+	if storageWriter, isStorage := logBuffer.targetWriter.(syncWriter); isStorage && !isStandardStream(logBuffer.targetWriter) {
+		return storageWriter.Sync()
+	}
+
+	return nil
+}
+
+// Close stops the periodic flush, flushes the buffer and closes the wrapped writer
+func (logBuffer *bufferWriter) Close() error {
+	logBuffer.stopOnce.Do(func() {
+		close(logBuffer.stopChannel)
+	})
+
+	flushError := logBuffer.Flush()
+
+	if logCloser, isCloser := logBuffer.targetWriter.(io.Closer); isCloser && !isStandardStream(logBuffer.targetWriter) {
+		if closeError := logCloser.Close(); flushError == nil {
+			flushError = closeError
+		}
+	}
+
+	return flushError
+}
+
+// needsRotation reports whether the wrapped writer meets a rotation condition
+// The buffered output counts towards the size of the log file
+func (logBuffer *bufferWriter) needsRotation(pendingSize int64) bool {
+	logRotate, isRotator := logBuffer.targetWriter.(entryRotator)
+
+	if !isRotator {
+		return false
+	}
+
+	logBuffer.bufferLock.Lock()
+	defer logBuffer.bufferLock.Unlock()
+
+	return logRotate.needsRotation(pendingSize + int64(logBuffer.outputBuffer.Buffered()))
+}
+
+// rotateIfNeeded flushes the buffer and lets the wrapped writer rotate when it meets a rotation condition
+// The wrapped writer rotates even when the flush failed, as a fresh log file may recover the output
+func (logBuffer *bufferWriter) rotateIfNeeded() error {
+	if !logBuffer.needsRotation(0) {
+		return nil
+	}
+
+	logRotate := logBuffer.targetWriter.(entryRotator)
+
+	logBuffer.bufferLock.Lock()
+	defer logBuffer.bufferLock.Unlock()
+
+	flushError := logBuffer.flushBuffer()
+
+	return errors.Join(flushError, logRotate.rotateIfNeeded())
+}
+
+// currentFile returns the log file of the wrapped writer
+func (logBuffer *bufferWriter) currentFile() *os.File {
+	if logFile, isFile := logBuffer.targetWriter.(fileProvider); isFile {
+		return logFile.currentFile()
+	}
+
+	if fileDescriptor, isFile := logBuffer.targetWriter.(*os.File); isFile {
+		return fileDescriptor
+	}
+
+	return nil
+}
+
+// reopen flushes the buffer and lets the wrapped writer reopen its log file
+// The log file is reopened even when the flush failed, as the reopened file may recover the output
+func (logBuffer *bufferWriter) reopen() error {
+	logReopen, isReopener := logBuffer.targetWriter.(fileReopener)
+
+	if !isReopener {
+		return errors.New("log instance has no file path to reopen")
+	}
+
+	logBuffer.bufferLock.Lock()
+	defer logBuffer.bufferLock.Unlock()
+
+	flushError := logBuffer.flushBuffer()
+
+	return errors.Join(flushError, logReopen.reopen())
+}
@@ -0,0 +1,53 @@
+// Buffered Output Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingWriter fails its first write and records the later ones
+type failingWriter struct {
+	failedOnce    bool         // failedOnce reports that the failing write happened
+	writtenOutput bytes.Buffer // writtenOutput holds the content of the successful writes
+}
+
+// Write fails the first time and records the content afterwards
+func (testWriter *failingWriter) Write(writeContent []byte) (int, error) {
+	if !testWriter.failedOnce {
+		testWriter.failedOnce = true
+		return 0, errors.New("the log collector is unreachable")
+	}
+
+	return testWriter.writtenOutput.Write(writeContent)
+}
+
+func TestBufferRecoversAfterFailedFlush(t *testing.T) {
+	targetWriter := &failingWriter{}
+	logInstance := InitializeWriter(targetWriter, WithFile(true), WithTerminal(false), WithBuffer(4096, 0))
+
+	logInstance.FLog(nil, "lost message")
+
+	if flushError := logInstance.Flush(); flushError == nil {
+		t.Fatal("first flush succeeded, want the write error")
+	}
+
+	logInstance.FLog(nil, "delivered message")
+
+	if flushError := logInstance.Flush(); flushError != nil {
+		t.Fatalf("flush after the writer recovered failed: %v", flushError)
+	}
+
+	if writtenOutput := targetWriter.writtenOutput.String(); !strings.Contains(writtenOutput, "delivered message") {
+		t.Errorf("written output %q misses the message logged after the failure", writtenOutput)
+	}
+}
@@ -0,0 +1,83 @@
+// Build Information Enrichment
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// WithBuildInfo attaches the module path, the VCS revision and the Go version of the running binary
+// as the module, revision and go_version fields of every message
+// Fields the binary does not record are left out
+func WithBuildInfo() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.boundFields = append(logInstance.boundFields, buildFields(false)...)
+	}
+}
+
+// LogBuildInfo writes a single message describing the running binary to the configured destinations
+// Along with the fields of WithBuildInfo, it carries the module version and whether the working tree was modified
+// Fields already attached with WithBuildInfo are not repeated
+func (logInstance *LogInstance) LogBuildInfo() {
+	logInstance.logLock.Lock()
+	boundFields := logInstance.boundFields
+	logInstance.logLock.Unlock()
+
+	var messageFields []Field
+
+	for _, buildField := range buildFields(true) {
+		isBound := false
+
+		for _, boundField := range boundFields {
+			isBound = isBound || boundField.Key == buildField.Key
+		}
+
+		if !isBound {
+			messageFields = append(messageFields, buildField)
+		}
+	}
+
+	printConfigured(logInstance, MessageNormal, nil, "build information", messageFields)
+}
+
+// buildFields returns the build information of the running binary as fields
+// The module version, the commit time and the modification flag are only added on detailed requests
+func buildFields(needDetails bool) []Field {
+	buildInformation, isAvailable := debug.ReadBuildInfo()
+
+	if !isAvailable {
+		return []Field{String("go_version", runtime.Version())}
+	}
+
+	var logFields []Field
+
+	if buildInformation.Main.Path != "" {
+		logFields = append(logFields, String("module", buildInformation.Main.Path))
+	}
+
+	if needDetails && buildInformation.Main.Version != "" {
+		logFields = append(logFields, String("module_version", buildInformation.Main.Version))
+	}
+
+	for _, buildSetting := range buildInformation.Settings {
+		switch {
+		case buildSetting.Key == "vcs.revision":
+			logFields = append(logFields, String("revision", buildSetting.Value))
+
+		case needDetails && buildSetting.Key == "vcs.time":
+			logFields = append(logFields, String("revision_time", buildSetting.Value))
+
+		case needDetails && buildSetting.Key == "vcs.modified":
+			logFields = append(logFields, String("modified", buildSetting.Value))
+		}
+	}
+
+	return append(logFields, String("go_version", buildInformation.GoVersion))
+}
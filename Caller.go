@@ -0,0 +1,127 @@
+// Caller Information Capture
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	maximumCallerDepth int = 32  // maximumCallerDepth limits how many stack frames are inspected to find the caller
+	maximumStackDepth  int = 128 // maximumStackDepth limits how many stack frames are written to a stack trace
+)
+
+// packagePrefix is the prefix of every function name in this package
+var packagePrefix = reflect.TypeOf(LogInstance{}).PkgPath() + "."
+
+// callerInformation holds the source location of a log call
+type callerInformation struct {
+	callerFile     string // callerFile is the path of the source file
+	callerLine     int    // callerLine is the line number in the source file
+	callerFunction string // callerFunction is the fully qualified function name
+}
+
+// WithCaller records the source file, line and function of every log call
+// The frames of this package are skipped automatically, and callerSkip skips additional
+// frames for applications that wrap the logging methods in helpers of their own
+func WithCaller(callerSkip int) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.captureCaller = true
+		logInstance.callerSkip = callerSkip
+	}
+}
+
+// captureCaller returns the source location of the log call outside this package
+func captureCaller(callerSkip int) *callerInformation {
+	programCounters := make([]uintptr, maximumCallerDepth)
+	frameCount := runtime.Callers(2, programCounters)
+	callerFrames := runtime.CallersFrames(programCounters[:frameCount])
+
+	for {
+		callerFrame, hasMore := callerFrames.Next()
+
+		if !strings.HasPrefix(callerFrame.Function, packagePrefix) {
+			if callerSkip <= 0 {
+				return &callerInformation{
+					callerFile:     callerFrame.File,
+					callerLine:     callerFrame.Line,
+					callerFunction: callerFrame.Function,
+				}
+			}
+
+			callerSkip--
+		}
+
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+// WithStacktrace attaches the stack trace of the log call to every message at or above minimumLevel
+// The frames of this package are left out of the stack trace
+func WithStacktrace(minimumLevel Level) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.captureStack = true
+		logInstance.stackLevel = minimumLevel
+	}
+}
+
+// captureStack returns the formatted stack trace of the log call outside this package
+// Every frame is written as the function name followed by the indented file and line
+func captureStack() string {
+	programCounters := make([]uintptr, maximumStackDepth)
+	frameCount := runtime.Callers(2, programCounters)
+	callerFrames := runtime.CallersFrames(programCounters[:frameCount])
+
+	var stackBuilder strings.Builder
+
+	insidePackage := true
+
+	for {
+		callerFrame, hasMore := callerFrames.Next()
+
+		if insidePackage && strings.HasPrefix(callerFrame.Function, packagePrefix) {
+			if !hasMore {
+				break
+			}
+
+			continue
+		}
+
+		insidePackage = false
+
+		if stackBuilder.Len() > 0 {
+			stackBuilder.WriteByte('\n')
+		}
+
+		stackBuilder.WriteString(callerFrame.Function)
+		stackBuilder.WriteString("\n\t")
+		stackBuilder.WriteString(callerFrame.File)
+		stackBuilder.WriteByte(':')
+		stackBuilder.WriteString(strconv.Itoa(callerFrame.Line))
+
+		if !hasMore {
+			break
+		}
+	}
+
+	return stackBuilder.String()
+}
+
+// shortFile returns the source file with its parent directory and the line number
+func (entryCaller *callerInformation) shortFile() string {
+	parentDirectory := filepath.Base(filepath.Dir(entryCaller.callerFile))
+
+	return parentDirectory + "/" + filepath.Base(entryCaller.callerFile) + ":" + strconv.Itoa(entryCaller.callerLine)
+}
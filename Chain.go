@@ -0,0 +1,217 @@
+// Hash-Chained Audit Output
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// chainTailSize limits how much of an existing log file is read to continue its hash chain
+const chainTailSize int64 = 1 << 20
+
+// chainHashSize is the length of the hexadecimal hash closing every chained line
+const chainHashSize int = 2 * sha256.Size
+
+// chainJSONKey and chainTextKey introduce the hash of a JSON line and of any other line
+const (
+	chainJSONKey string = `"chain":"`
+	chainTextKey string = " chain="
+)
+
+// errChainKey is returned when a hash chain is verified without a key
+var errChainKey = errors.New("the hash chain key is empty")
+
+// hashChain computes the hash of every line from its content and the hash of the line before it
+type hashChain struct {
+	chainKey     []byte // chainKey is the secret key of the HMAC
+	previousHash string // previousHash is the hash of the last written line
+}
+
+// WithHashChain makes the file output tamper evident by closing every line with a chain hash
+// The hash is an HMAC-SHA256 keyed with hmacKey over the line and the hash of the line before it,
+// written as a chain field of JSON lines and as a trailing chain=<hash> on other lines
+// The chain continues across rotated files and, when appending, across restarts of the process
+func WithHashChain(hmacKey []byte) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.chainKey = append([]byte{}, hmacKey...)
+	}
+}
+
+// VerifyHashChain checks every line of chainedLog against the chain hashes computed with hmacKey
+// previousHash is empty for the first file of a chain and the hash returned for the previous file after a rotation
+// It returns the hash of the last line, which also detects lines removed from the end when compared with a recorded hash,
+// or an error naming the first line that was altered, inserted or removed
+func VerifyHashChain(chainedLog io.Reader, hmacKey []byte, previousHash string) (string, error) {
+	if len(hmacKey) == 0 {
+		return previousHash, errChainKey
+	}
+
+	logChain := &hashChain{chainKey: hmacKey, previousHash: previousHash}
+	lineReader := bufio.NewReader(chainedLog)
+
+	for lineNumber := 1; ; lineNumber++ {
+		chainedLine, readError := lineReader.ReadBytes('\n')
+
+		if readError != nil && readError != io.EOF {
+			return logChain.previousHash, readError
+		}
+
+		chainedLine = bytes.TrimSuffix(chainedLine, []byte("\n"))
+
+		if len(chainedLine) == 0 && readError == io.EOF {
+			return logChain.previousHash, nil
+		}
+
+		plainLine, lineHash, isChained := splitChainedLine(chainedLine)
+
+		if !isChained {
+			return logChain.previousHash, fmt.Errorf("line %d of the log carries no chain hash", lineNumber)
+		}
+
+		if !hmac.Equal([]byte(lineHash), []byte(logChain.lineHash(plainLine))) {
+			return logChain.previousHash, fmt.Errorf("line %d of the log breaks the hash chain", lineNumber)
+		}
+
+		logChain.previousHash = lineHash
+
+		if readError == io.EOF {
+			return logChain.previousHash, nil
+		}
+	}
+}
+
+// chainOutput wraps the file output in a writer closing every line with its chain hash when the chain is enabled
+// The chain starts from previousHash, the hash of the last line already in the output
+func (logInstance *LogInstance) chainOutput(logWriter io.Writer, previousHash string) io.Writer {
+	if logInstance.chainKey == nil || logWriter == io.Discard {
+		return logWriter
+	}
+
+	logChain := &hashChain{chainKey: logInstance.chainKey, previousHash: previousHash}
+
+	return &lineWriter{targetWriter: logWriter, writeLines: logChain.writeLines, holdIncomplete: true}
+}
+
+// lastChainHash returns the chain hash of the last line of the log file, or an empty string without one
+func lastChainHash(logPath string) string {
+	logFile, openError := os.Open(logPath)
+
+	if openError != nil {
+		return ""
+	}
+
+	defer logFile.Close()
+
+	fileInformation, statError := logFile.Stat()
+
+	if statError != nil {
+		return ""
+	}
+
+	tailOffset := fileInformation.Size() - chainTailSize
+
+	if tailOffset < 0 {
+		tailOffset = 0
+	}
+
+	fileTail := make([]byte, fileInformation.Size()-tailOffset)
+
+	if _, readError := logFile.ReadAt(fileTail, tailOffset); readError != nil && readError != io.EOF {
+		return ""
+	}
+
+	fileTail = bytes.TrimSuffix(fileTail, []byte("\n"))
+	_, lineHash, _ := splitChainedLine(fileTail[bytes.LastIndexByte(fileTail, '\n')+1:])
+
+	return lineHash
+}
+
+// writeLines closes every line with its chain hash and writes the lines to the wrapped writer
+func (logChain *hashChain) writeLines(targetWriter io.Writer, logLines []byte) error {
+	chainedLines := make([]byte, 0, len(logLines)+bytes.Count(logLines, []byte("\n"))*(chainHashSize+len(chainTextKey)+2))
+
+	for len(logLines) > 0 {
+		lineEnd := bytes.IndexByte(logLines, '\n')
+		plainLine, lineBreak := logLines, []byte(nil)
+
+		if lineEnd >= 0 {
+			plainLine, lineBreak = logLines[:lineEnd], logLines[lineEnd:lineEnd+1]
+		}
+
+		lineHash := logChain.lineHash(plainLine)
+		logChain.previousHash = lineHash
+
+		chainedLines = append(appendChainHash(chainedLines, plainLine, lineHash), lineBreak...)
+		logLines = logLines[len(plainLine)+len(lineBreak):]
+	}
+
+	_, writeError := targetWriter.Write(chainedLines)
+
+	return writeError
+}
+
+// lineHash returns the hexadecimal HMAC of the hash of the previous line and the plain line
+func (logChain *hashChain) lineHash(plainLine []byte) string {
+	lineMAC := hmac.New(sha256.New, logChain.chainKey)
+	lineMAC.Write([]byte(logChain.previousHash))
+	lineMAC.Write([]byte("\n"))
+	lineMAC.Write(plainLine)
+
+	return hex.EncodeToString(lineMAC.Sum(nil))
+}
+
+// appendChainHash appends the plain line closed with its chain hash
+// A JSON object receives the hash as its last key, any other line as a trailing chain=<hash>
+func appendChainHash(chainedLines []byte, plainLine []byte, lineHash string) []byte {
+	if !isJSONLine(plainLine) {
+		return append(append(append(chainedLines, plainLine...), chainTextKey...), lineHash...)
+	}
+
+	chainedLines = append(chainedLines, plainLine[:len(plainLine)-1]...)
+
+	if len(plainLine) > 2 {
+		chainedLines = append(chainedLines, ',')
+	}
+
+	return append(append(append(chainedLines, chainJSONKey...), lineHash...), `"}`...)
+}
+
+// splitChainedLine returns the plain line and the chain hash of a chained line
+func splitChainedLine(chainedLine []byte) ([]byte, string, bool) {
+	jsonSuffix := len(chainJSONKey) + chainHashSize + 2
+
+	if isJSONLine(chainedLine) && len(chainedLine) >= jsonSuffix+1 &&
+		bytes.HasPrefix(chainedLine[len(chainedLine)-jsonSuffix:], []byte(chainJSONKey)) {
+		hashStart := len(chainedLine) - chainHashSize - 2
+		plainLine := bytes.TrimSuffix(chainedLine[:len(chainedLine)-jsonSuffix], []byte(","))
+
+		return append(append([]byte{}, plainLine...), '}'), string(chainedLine[hashStart : hashStart+chainHashSize]), true
+	}
+
+	textSuffix := len(chainTextKey) + chainHashSize
+
+	if len(chainedLine) >= textSuffix && bytes.HasPrefix(chainedLine[len(chainedLine)-textSuffix:], []byte(chainTextKey)) {
+		return chainedLine[:len(chainedLine)-textSuffix], string(chainedLine[len(chainedLine)-chainHashSize:]), true
+	}
+
+	return nil, "", false
+}
+
+// isJSONLine reports whether the line holds a JSON object
+func isJSONLine(logLine []byte) bool {
+	return len(logLine) >= 2 && logLine[0] == '{' && logLine[len(logLine)-1] == '}'
+}
@@ -0,0 +1,119 @@
+// Log Instance Lifecycle
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"io"
+	"os"
+)
+
+// flushWriter is implemented by writers that buffer their output
+type flushWriter interface {
+	Flush() error
+}
+
+// syncWriter is implemented by writers that can commit their output to stable storage
+type syncWriter interface {
+	Sync() error
+}
+
+// Flush commits the file output to the underlying storage
+// Buffered writers, destinations and hooks are flushed and files are synced to disk
+// With asynchronous logging, it waits until every queued message is written first
+func (logInstance *LogInstance) Flush() error {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	var flushError error
+
+	logInstance.runTaskWait(func() {
+		flushError = flushOutput(logInstance.logOutput.logWriter)
+
+		if destinationError := flushDestinations(logInstance); flushError == nil {
+			flushError = destinationError
+		}
+
+		if hookError := flushHooks(logInstance); flushError == nil {
+			flushError = hookError
+		}
+	})
+
+	return flushError
+}
+
+// Close flushes the file output and closes the underlying destination along with the additional destinations
+// Pending repetitions of a collapsed message are reported first, hooks are flushed but stay open
+// and the audit trail is closed until its next event
+// With asynchronous logging, every queued message is written first and any later message is dropped
+// Any later file output is discarded
+func (logInstance *LogInstance) Close() error {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	var flushError, closeError error
+
+	if logInstance.logRepeat != nil {
+		logInstance.logRepeat.report(logInstance.logClock())
+	}
+
+	logInstance.runTaskWait(func() {
+		flushError = flushOutput(logInstance.logOutput.logWriter)
+
+		if logCloser, isCloser := logInstance.logOutput.logWriter.(io.Closer); isCloser && !isStandardStream(logInstance.logOutput.logWriter) {
+			closeError = logCloser.Close()
+		}
+
+		if destinationError := closeDestinations(logInstance); closeError == nil {
+			closeError = destinationError
+		}
+
+		if hookError := flushHooks(logInstance); closeError == nil {
+			closeError = hookError
+		}
+
+		if logInstance.logAudit != nil {
+			if auditError := logInstance.logAudit.close(); closeError == nil {
+				closeError = auditError
+			}
+		}
+
+		logInstance.LogDestination = nil
+		logInstance.logOutput.logWriter = io.Discard
+	})
+
+	if logInstance.logOutput.logQueue != nil {
+		logInstance.logOutput.logQueue.stop()
+	}
+
+	if flushError != nil {
+		return flushError
+	}
+
+	return closeError
+}
+
+// flushOutput flushes and syncs the selected writer when it supports it
+func flushOutput(logWriter io.Writer) error {
+	if bufferedWriter, isBuffered := logWriter.(flushWriter); isBuffered {
+		if flushError := bufferedWriter.Flush(); flushError != nil {
+			return flushError
+		}
+	}
+
+	if storageWriter, isStorage := logWriter.(syncWriter); isStorage && !isStandardStream(logWriter) {
+		return storageWriter.Sync()
+	}
+
+	return nil
+}
+
+// isStandardStream reports whether the writer is the standard output or error stream
+func isStandardStream(logWriter io.Writer) bool {
+	return logWriter == os.Stdout || logWriter == os.Stderr
+}
@@ -0,0 +1,141 @@
+// AWS CloudWatch Logs Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// cloudWatchMaximumBatch is the maximum number of events accepted by a single PutLogEvents call
+const cloudWatchMaximumBatch int = 10000
+
+// ErrCloudWatchThrottled is returned by a CloudWatch client when the request was throttled
+// Throttled batches are retried with the backoff of the batch settings
+var ErrCloudWatchThrottled = errors.New("the CloudWatch Logs request was throttled")
+
+// CloudWatchEvent is a single log event of a PutLogEvents call
+type CloudWatchEvent struct {
+	Timestamp int64  // Timestamp is the event time in milliseconds since the Unix epoch
+	Message   string // Message is the JSON encoded log message
+}
+
+// CloudWatchSequenceError is returned by a CloudWatch client when the sequence token was rejected
+// The batch is sent again right away with the expected token
+type CloudWatchSequenceError struct {
+	ExpectedToken string // ExpectedToken is the sequence token CloudWatch Logs expects next
+}
+
+// Error returns the description of the rejected sequence token
+func (sequenceError CloudWatchSequenceError) Error() string {
+	return "the CloudWatch Logs sequence token was rejected, expected " + sequenceError.ExpectedToken
+}
+
+// CloudWatchClient sends log events with the PutLogEvents call of CloudWatch Logs
+// It is implemented by a thin wrapper around the AWS SDK client used by the application
+// Throttling is reported as ErrCloudWatchThrottled and rejected sequence tokens as CloudWatchSequenceError
+type CloudWatchClient interface {
+	PutLogEvents(logGroup string, logStream string, logEvents []CloudWatchEvent,
+		sequenceToken string) (string, error) // PutLogEvents sends the events and returns the next sequence token
+}
+
+// CloudWatchDestination sends log messages to a log stream of CloudWatch Logs in batches
+type CloudWatchDestination struct {
+	cloudWatchClient CloudWatchClient // cloudWatchClient sends the batches
+	logGroup         string           // logGroup is the log group receiving the events
+	logStream        string           // logStream is the log stream receiving the events
+	sequenceLock     sync.Mutex       // sequenceLock guards the sequence token
+	sequenceToken    string           // sequenceToken is the token of the next PutLogEvents call
+	logBatch         *batchSender     // logBatch collects and delivers the encoded messages
+}
+
+// NewCloudWatchDestination returns a destination sending log messages to logStream of logGroup
+// Batches hold at most 10000 events as required by CloudWatch Logs
+func NewCloudWatchDestination(cloudWatchClient CloudWatchClient, logGroup string, logStream string,
+	batchSettings BatchSettings) *CloudWatchDestination {
+	if batchSettings.BatchSize <= 0 || batchSettings.BatchSize > cloudWatchMaximumBatch {
+		batchSettings.BatchSize = cloudWatchMaximumBatch
+	}
+
+	cloudWatchDestination := &CloudWatchDestination{
+		cloudWatchClient: cloudWatchClient,
+		logGroup:         logGroup,
+		logStream:        logStream,
+	}
+
+	cloudWatchDestination.logBatch = newBatchSender(batchSettings, cloudWatchDestination.deliver)
+
+	return cloudWatchDestination
+}
+
+// WriteEntry encodes the log message as a log event and queues it for delivery
+func (cloudWatchDestination *CloudWatchDestination) WriteEntry(logEntry Entry) error {
+	return cloudWatchDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message as a log event and queues it for delivery
+func (cloudWatchDestination *CloudWatchDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	cloudWatchDestination.logBatch.add(logInstance, CloudWatchEvent{
+		Timestamp: messageEntry.entryTime.UnixMilli(),
+		Message:   encodeJSON(jsonTime(logInstance, messageEntry.entryTime), messageEntry),
+	})
+
+	return nil
+}
+
+// Flush delivers every pending message
+func (cloudWatchDestination *CloudWatchDestination) Flush() error {
+	return cloudWatchDestination.logBatch.Flush()
+}
+
+// Close delivers every pending message and stops the background delivery
+func (cloudWatchDestination *CloudWatchDestination) Close() error {
+	return cloudWatchDestination.logBatch.Close()
+}
+
+// deliver sends the batch in chronological order and keeps the returned sequence token
+func (cloudWatchDestination *CloudWatchDestination) deliver(batchItems []interface{}) error {
+	logEvents := make([]CloudWatchEvent, len(batchItems))
+
+	for itemIndex, batchItem := range batchItems {
+		logEvents[itemIndex] = batchItem.(CloudWatchEvent)
+	}
+
+	sort.SliceStable(logEvents, func(firstIndex int, secondIndex int) bool {
+		return logEvents[firstIndex].Timestamp < logEvents[secondIndex].Timestamp
+	})
+
+	cloudWatchDestination.sequenceLock.Lock()
+	defer cloudWatchDestination.sequenceLock.Unlock()
+
+	nextToken, putError := cloudWatchDestination.cloudWatchClient.PutLogEvents(cloudWatchDestination.logGroup,
+		cloudWatchDestination.logStream, logEvents, cloudWatchDestination.sequenceToken)
+
+	var sequenceError CloudWatchSequenceError
+
+	if errors.As(putError, &sequenceError) {
+		cloudWatchDestination.sequenceToken = sequenceError.ExpectedToken
+
+		nextToken, putError = cloudWatchDestination.cloudWatchClient.PutLogEvents(cloudWatchDestination.logGroup,
+			cloudWatchDestination.logStream, logEvents, cloudWatchDestination.sequenceToken)
+	}
+
+	if errors.Is(putError, ErrCloudWatchThrottled) {
+		return retryableError{deliveryError: putError}
+	}
+
+	if putError != nil {
+		return putError
+	}
+
+	cloudWatchDestination.sequenceToken = nextToken
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Rotated File Compression
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// compressedExtension is appended to the name of compressed rotated files
+const compressedExtension string = ".gz"
+
+// WithCompression compresses rotated log files with gzip in the background
+// The compressed file replaces the rotated file once it is complete
+func WithCompression() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logRotation.compressFiles = true
+	}
+}
+
+// compressFile compresses the selected file with gzip and removes the original
+// The compressed data is written to a temporary file first so a partial file is never left behind
+func compressFile(filePath string) error {
+	sourceFile, openError := os.Open(filePath)
+
+	if openError != nil {
+		return openError
+	}
+
+	defer sourceFile.Close()
+
+	temporaryPath := filePath + compressedExtension + ".tmp"
+	targetFile, createError := os.Create(temporaryPath)
+
+	if createError != nil {
+		return createError
+	}
+
+	gzipWriter := gzip.NewWriter(targetFile)
+	_, copyError := io.Copy(gzipWriter, sourceFile)
+
+	if closeError := gzipWriter.Close(); copyError == nil {
+		copyError = closeError
+	}
+
+	if closeError := targetFile.Close(); copyError == nil {
+		copyError = closeError
+	}
+
+	if copyError != nil {
+		os.Remove(temporaryPath)
+		return copyError
+	}
+
+	if renameError := os.Rename(temporaryPath, filePath+compressedExtension); renameError != nil {
+		os.Remove(temporaryPath)
+		return renameError
+	}
+
+	sourceFile.Close()
+
+	return os.Remove(filePath)
+}
@@ -0,0 +1,467 @@
+// Declarative Configuration
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config describes a log instance declaratively
+// LoadConfig reads it from a JSON, YAML or TOML file, whose keys are the JSON names of the fields
+type Config struct {
+	File           string                 `json:"file"`            // File is the log file path, the file output is discarded when empty
+	Append         bool                   `json:"append"`          // Append selects appending to the log file instead of truncating it
+	Level          string                 `json:"level"`           // Level is the name of the minimum level, such as debug
+	Format         string                 `json:"format"`          // Format is the format of the file and terminal output: text, json or logfmt
+	TerminalFormat string                 `json:"terminal_format"` // TerminalFormat replaces the format of the terminal output, console selects the console layout
+	Terminal       *bool                  `json:"terminal"`        // Terminal selects the terminal output, enabled when missing
+	StderrLevel    string                 `json:"stderr_level"`    // StderrLevel is the minimum level written to the standard error stream
+	Color          string                 `json:"color"`           // Color selects colored terminal output: auto, always or never
+	TimeFormat     string                 `json:"time_format"`     // TimeFormat is a layout of the time package, or rfc3339, rfc3339nano, datetime or kitchen
+	UTC            bool                   `json:"utc"`             // UTC selects writing the time in UTC
+	Caller         bool                   `json:"caller"`          // Caller selects recording the source location of every message
+	Stacktrace     string                 `json:"stacktrace"`      // Stacktrace is the minimum level of messages carrying a stack trace
+	Debug          string                 `json:"debug"`           // Debug is the selector of the named loggers writing debug messages, such as api:*,db:query
+	Rotation       RotationConfig         `json:"rotation"`        // Rotation configures the rotation of the log file
+	BufferSize     int                    `json:"buffer_size"`     // BufferSize is the size of the file output buffer, unbuffered when zero
+	FlushInterval  string                 `json:"flush_interval"`  // FlushInterval is the duration between flushes of the buffer, such as 1s
+	Async          int                    `json:"async"`           // Async is the queue size of asynchronous logging, synchronous when zero
+	Overflow       string                 `json:"overflow"`        // Overflow selects what a full asynchronous queue does: block, drop_oldest or drop_newest
+	Service        string                 `json:"service"`         // Service is written as the service field of every message
+	Version        string                 `json:"version"`         // Version is written as the version field of every message
+	Fields         map[string]interface{} `json:"fields"`          // Fields are written with every message
+	Destinations   []DestinationConfig    `json:"destinations"`    // Destinations are the additional outputs receiving every message
+}
+
+// RotationConfig describes the rotation of the log file
+type RotationConfig struct {
+	MaxSize    int64  `json:"max_size"`    // MaxSize is the size in bytes a log file may grow to before it is rotated
+	MaxBackups int    `json:"max_backups"` // MaxBackups is the number of rotated files kept
+	MaxAge     string `json:"max_age"`     // MaxAge is the age after which rotated files are removed, such as 168h
+	Interval   string `json:"interval"`    // Interval rotates the log file hourly or daily
+	Pattern    string `json:"pattern"`     // Pattern is the time layout naming the files rotated on the interval
+	Compress   bool   `json:"compress"`    // Compress selects gzip compression of the rotated files
+}
+
+// DestinationConfig describes an additional output
+// Type is file, stdout, stderr, syslog or http
+type DestinationConfig struct {
+	Type    string `json:"type"`    // Type selects the kind of destination
+	Path    string `json:"path"`    // Path is the file written by a file destination
+	Network string `json:"network"` // Network is the network of a syslog destination, the local syslog daemon when empty
+	Address string `json:"address"` // Address is the address of a syslog destination or the URL of an http destination
+	Tag     string `json:"tag"`     // Tag is the tag of a syslog destination
+	Format  string `json:"format"`  // Format is the format of a file or stream destination: text, json or logfmt
+	Level   string `json:"level"`   // Level is the minimum level of a file or stream destination
+}
+
+// FromConfigFile returns a log instance configured by the JSON, YAML or TOML file at configPath
+// The format is selected by the extension of the file: .json, .yaml, .yml or .toml
+func FromConfigFile(configPath string) (*LogInstance, error) {
+	logConfig, loadError := LoadConfig(configPath)
+
+	if loadError != nil {
+		return nil, loadError
+	}
+
+	return FromConfig(logConfig)
+}
+
+// LoadConfig reads the configuration from the JSON, YAML or TOML file at configPath
+// Unknown keys are reported as errors so misspelled settings are not silently ignored
+func LoadConfig(configPath string) (Config, error) {
+	var logConfig Config
+
+	configContent, readError := os.ReadFile(configPath)
+
+	if readError != nil {
+		return logConfig, readError
+	}
+
+	var configDocument interface{}
+	var decodeError error
+
+	switch strings.ToLower(filepath.Ext(configPath)) {
+	case ".json":
+		configDocument = json.RawMessage(configContent)
+
+	case ".yaml", ".yml":
+		decodeError = yaml.Unmarshal(configContent, &configDocument)
+
+	case ".toml":
+		decodeError = toml.Unmarshal(configContent, &configDocument)
+
+	default:
+		return logConfig, fmt.Errorf("configuration file %q has no json, yaml or toml extension", configPath)
+	}
+
+	if decodeError != nil {
+		return logConfig, fmt.Errorf("unable to read the configuration file %q because %w", configPath, decodeError)
+	}
+
+	jsonDocument, encodeError := json.Marshal(configDocument)
+
+	if encodeError != nil {
+		return logConfig, fmt.Errorf("unable to read the configuration file %q because %w", configPath, encodeError)
+	}
+
+	configDecoder := json.NewDecoder(bytes.NewReader(jsonDocument))
+	configDecoder.DisallowUnknownFields()
+
+	if decodeError = configDecoder.Decode(&logConfig); decodeError != nil {
+		return logConfig, fmt.Errorf("unable to read the configuration file %q because %w", configPath, decodeError)
+	}
+
+	return logConfig, nil
+}
+
+// FromConfig returns a log instance configured by the configuration
+// Destinations are opened along with the log file and closed again when the log instance cannot be created
+func FromConfig(logConfig Config) (*LogInstance, error) {
+	logOptions, optionError := logConfig.options()
+
+	if optionError != nil {
+		return nil, optionError
+	}
+
+	logDestinations, destinationError := logConfig.openDestinations()
+
+	if destinationError != nil {
+		return nil, destinationError
+	}
+
+	for _, logDestination := range logDestinations {
+		logOptions = append(logOptions, WithDestination(logDestination))
+	}
+
+	logState := &configState{logConfig: logConfig, logDestinations: logDestinations}
+
+	if logConfig.File == "" {
+		logInstance := InitializeWriter(nil, logOptions...)
+		logInstance.logOutput.logConfig = logState
+
+		return logInstance, nil
+	}
+
+	logInstance, initializeError := InitializeE(logConfig.File, logOptions...)
+
+	if initializeError != nil {
+		closeConfigDestinations(logDestinations)
+		return nil, initializeError
+	}
+
+	logInstance.logOutput.logConfig = logState
+
+	return logInstance, nil
+}
+
+// options returns the options matching the configuration, or an error naming the first invalid setting
+func (logConfig Config) options() ([]Option, error) {
+	var logOptions []Option
+
+	if logConfig.File != "" {
+		logOptions = append(logOptions, WithFile(true))
+	}
+
+	if logConfig.Append {
+		logOptions = append(logOptions, WithAppend())
+	}
+
+	if logConfig.Terminal != nil {
+		logOptions = append(logOptions, WithTerminal(*logConfig.Terminal))
+	}
+
+	levelSettings := []struct {
+		levelName   string
+		levelOption func(Level) Option
+	}{
+		{logConfig.Level, WithLevel},
+		{logConfig.StderrLevel, WithStderrLevel},
+		{logConfig.Stacktrace, WithStacktrace},
+	}
+
+	for _, levelSetting := range levelSettings {
+		if levelSetting.levelName == "" {
+			continue
+		}
+
+		logLevel, parseError := ParseLevel(levelSetting.levelName)
+
+		if parseError != nil {
+			return nil, parseError
+		}
+
+		logOptions = append(logOptions, levelSetting.levelOption(logLevel))
+	}
+
+	logOptions = append(logOptions, WithDebugSelector(logConfig.Debug))
+
+	if logConfig.Format != "" {
+		outputFormat, parseError := parseFormat(logConfig.Format)
+
+		if parseError != nil {
+			return nil, parseError
+		}
+
+		logOptions = append(logOptions, WithFormat(outputFormat))
+	}
+
+	switch strings.ToLower(logConfig.Color) {
+	case "":
+
+	case "auto":
+		logOptions = append(logOptions, WithColor(true), WithColorMode(ColorModeAuto))
+
+	case "always":
+		logOptions = append(logOptions, WithColor(true), WithColorMode(ColorModeAlways))
+
+	case "never":
+		logOptions = append(logOptions, WithColor(false), WithColorMode(ColorModeNever))
+
+	default:
+		return nil, fmt.Errorf("color mode %q is not auto, always or never", logConfig.Color)
+	}
+
+	switch strings.ToLower(logConfig.TerminalFormat) {
+	case "":
+
+	case "console":
+		logOptions = append(logOptions, WithTerminalFormat(NewConsoleFormatter(strings.ToLower(logConfig.Color) != "never")))
+
+	default:
+		terminalFormat, parseError := parseFormat(logConfig.TerminalFormat)
+
+		if parseError != nil {
+			return nil, parseError
+		}
+
+		logOptions = append(logOptions, WithTerminalFormat(terminalFormat))
+	}
+
+	if logConfig.TimeFormat != "" {
+		logOptions = append(logOptions, WithTimeFormat(configTimeFormat(logConfig.TimeFormat)))
+	}
+
+	if logConfig.UTC {
+		logOptions = append(logOptions, WithUTC())
+	}
+
+	if logConfig.Caller {
+		logOptions = append(logOptions, WithCaller(0))
+	}
+
+	rotationOptions, rotationError := logConfig.Rotation.options()
+
+	if rotationError != nil {
+		return nil, rotationError
+	}
+
+	logOptions = append(logOptions, rotationOptions...)
+
+	if logConfig.BufferSize > 0 {
+		flushInterval, parseError := configDuration("flush_interval", logConfig.FlushInterval)
+
+		if parseError != nil {
+			return nil, parseError
+		}
+
+		logOptions = append(logOptions, WithBuffer(logConfig.BufferSize, flushInterval))
+	}
+
+	if logConfig.Async > 0 {
+		logOptions = append(logOptions, WithAsync(logConfig.Async))
+	}
+
+	switch strings.ToLower(logConfig.Overflow) {
+	case "", "block":
+
+	case "drop_oldest":
+		logOptions = append(logOptions, WithOverflowPolicy(OverflowDropOldest))
+
+	case "drop_newest":
+		logOptions = append(logOptions, WithOverflowPolicy(OverflowDropNewest))
+
+	default:
+		return nil, fmt.Errorf("overflow policy %q is not block, drop_oldest or drop_newest", logConfig.Overflow)
+	}
+
+	if logConfig.Service != "" {
+		logOptions = append(logOptions, WithService(logConfig.Service))
+	}
+
+	if logConfig.Version != "" {
+		logOptions = append(logOptions, WithVersion(logConfig.Version))
+	}
+
+	if len(logConfig.Fields) > 0 {
+		logOptions = append(logOptions, WithGlobalFields(logConfig.Fields))
+	}
+
+	return logOptions, nil
+}
+
+// options returns the options matching the rotation settings
+func (rotationConfig RotationConfig) options() ([]Option, error) {
+	var rotationOptions []Option
+
+	if rotationConfig.MaxSize > 0 {
+		rotationOptions = append(rotationOptions, WithMaxSize(rotationConfig.MaxSize))
+	}
+
+	if rotationConfig.MaxBackups > 0 {
+		rotationOptions = append(rotationOptions, WithMaxBackups(rotationConfig.MaxBackups))
+	}
+
+	if rotationConfig.MaxAge != "" {
+		maxAge, parseError := configDuration("max_age", rotationConfig.MaxAge)
+
+		if parseError != nil {
+			return nil, parseError
+		}
+
+		rotationOptions = append(rotationOptions, WithMaxAge(maxAge))
+	}
+
+	switch strings.ToLower(rotationConfig.Interval) {
+	case "":
+
+	case "hourly":
+		rotationOptions = append(rotationOptions, WithRotationInterval(RotateHourly, rotationConfig.Pattern))
+
+	case "daily":
+		rotationOptions = append(rotationOptions, WithRotationInterval(RotateDaily, rotationConfig.Pattern))
+
+	default:
+		return nil, fmt.Errorf("rotation interval %q is not hourly or daily", rotationConfig.Interval)
+	}
+
+	if rotationConfig.Compress {
+		rotationOptions = append(rotationOptions, WithCompression())
+	}
+
+	return rotationOptions, nil
+}
+
+// openDestinations opens the destinations of the configuration
+// If one cannot be opened, the ones opened before it are closed again
+func (logConfig Config) openDestinations() ([]Destination, error) {
+	logDestinations := make([]Destination, 0, len(logConfig.Destinations))
+
+	for _, destinationConfig := range logConfig.Destinations {
+		logDestination, openError := destinationConfig.open()
+
+		if openError != nil {
+			closeConfigDestinations(logDestinations)
+			return nil, openError
+		}
+
+		logDestinations = append(logDestinations, logDestination)
+	}
+
+	return logDestinations, nil
+}
+
+// open returns the destination described by the configuration
+func (destinationConfig DestinationConfig) open() (Destination, error) {
+	outputFormat, minimumLevel := FormatText, LevelTrace
+
+	if destinationConfig.Format != "" {
+		parsedFormat, parseError := parseFormat(destinationConfig.Format)
+
+		if parseError != nil {
+			return nil, parseError
+		}
+
+		outputFormat = parsedFormat
+	}
+
+	if destinationConfig.Level != "" {
+		parsedLevel, parseError := ParseLevel(destinationConfig.Level)
+
+		if parseError != nil {
+			return nil, parseError
+		}
+
+		minimumLevel = parsedLevel
+	}
+
+	switch strings.ToLower(destinationConfig.Type) {
+	case "file":
+		return NewFileDestination(destinationConfig.Path, outputFormat, minimumLevel)
+
+	case "stdout":
+		return NewWriterDestination(os.Stdout, outputFormat, minimumLevel), nil
+
+	case "stderr":
+		return NewWriterDestination(os.Stderr, outputFormat, minimumLevel), nil
+
+	case "syslog":
+		return destinationConfig.openSyslogDestination()
+
+	case "http":
+		return NewHTTPDestination(destinationConfig.Address, HTTPSettings{}), nil
+
+	default:
+		return nil, fmt.Errorf("destination type %q is not file, stdout, stderr, syslog or http", destinationConfig.Type)
+	}
+}
+
+// closeConfigDestinations closes the destinations opened for a log instance that could not be created
+func closeConfigDestinations(logDestinations []Destination) {
+	for _, logDestination := range logDestinations {
+		logDestination.Close()
+	}
+}
+
+// configTimeFormat returns the time layout matching the configured name, other values are layouts already
+func configTimeFormat(timeFormat string) string {
+	switch strings.ToLower(timeFormat) {
+	case "default":
+		return TimeFormatDefault
+
+	case "rfc3339":
+		return TimeFormatRFC3339
+
+	case "rfc3339nano":
+		return TimeFormatRFC3339Nano
+
+	case "datetime":
+		return TimeFormatDateTime
+
+	case "kitchen":
+		return TimeFormatKitchen
+
+	default:
+		return timeFormat
+	}
+}
+
+// configDuration parses the duration of the configuration key, an empty duration is zero
+func configDuration(configKey string, durationText string) (time.Duration, error) {
+	if durationText == "" {
+		return 0, nil
+	}
+
+	parsedDuration, parseError := time.ParseDuration(durationText)
+
+	if parseError != nil {
+		return 0, fmt.Errorf("%s %q is not a duration", configKey, durationText)
+	}
+
+	return parsedDuration, nil
+}
@@ -0,0 +1,23 @@
+// Configured Syslog Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build !windows && !plan9
+
+package GoLog
+
+// openSyslogDestination connects the syslog destination of the configuration
+func (destinationConfig DestinationConfig) openSyslogDestination() (Destination, error) {
+	syslogDestination, syslogError := NewSyslogDestination(destinationConfig.Network,
+		destinationConfig.Address, destinationConfig.Tag)
+
+	if syslogError != nil {
+		return nil, syslogError
+	}
+
+	return syslogDestination, nil
+}
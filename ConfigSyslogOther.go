@@ -0,0 +1,18 @@
+// Configured Syslog Destination on Windows and Plan 9
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build windows || plan9
+
+package GoLog
+
+import "errors"
+
+// openSyslogDestination fails because the syslog package does not exist on this platform
+func (destinationConfig DestinationConfig) openSyslogDestination() (Destination, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,88 @@
+// Configuration Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfig writes the configuration content to a file with the name in a temporary directory
+func writeConfig(t *testing.T, configName string, configContent string) string {
+	configPath := filepath.Join(t.TempDir(), configName)
+
+	if writeError := os.WriteFile(configPath, []byte(configContent), 0644); writeError != nil {
+		t.Fatalf("writing the configuration file failed: %v", writeError)
+	}
+
+	return configPath
+}
+
+func TestLoadConfigTOML(t *testing.T) {
+	configPath := writeConfig(t, "log.toml", `
+level = "debug"
+service = '''
+payments'''
+version = """
+1.2.0"""
+
+[rotation]
+max_size = 10
+
+[fields]
+region = "eu-west-1"
+"build.id" = 42
+
+[[destinations]]
+type = "stderr"
+format = "json"
+
+[[destinations]]
+type = "file"
+path = "audit.log"
+`)
+
+	logConfig, loadError := LoadConfig(configPath)
+
+	if loadError != nil {
+		t.Fatalf("loading the configuration failed: %v", loadError)
+	}
+
+	if logConfig.Level != "debug" || logConfig.Service != "payments" || logConfig.Version != "1.2.0" {
+		t.Errorf("level, service and version are %q, %q and %q, want debug, payments and 1.2.0",
+			logConfig.Level, logConfig.Service, logConfig.Version)
+	}
+
+	if logConfig.Fields["region"] != "eu-west-1" || logConfig.Fields["build.id"] != float64(42) {
+		t.Errorf("fields are %v, want the region and the build identifier", logConfig.Fields)
+	}
+
+	if len(logConfig.Destinations) != 2 || logConfig.Destinations[1].Path != "audit.log" {
+		t.Errorf("destinations are %+v, want the stderr and the file destination", logConfig.Destinations)
+	}
+}
+
+func TestLoadConfigTOMLErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		caseName      string // caseName names the subtest
+		configContent string // configContent is the invalid TOML document
+	}{
+		{caseName: "duplicate table", configContent: "[rotation]\nmax_size = 10\n\n[rotation]\nmax_age = \"24h\"\n"},
+		{caseName: "duplicate key", configContent: "level = \"debug\"\nlevel = \"info\"\n"},
+		{caseName: "unclosed string", configContent: "level = \"debug\n"},
+		{caseName: "unknown key", configContent: "levle = \"debug\"\n"},
+	} {
+		t.Run(testCase.caseName, func(t *testing.T) {
+			if _, loadError := LoadConfig(writeConfig(t, "log.toml", testCase.configContent)); loadError == nil {
+				t.Error("loading the configuration succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Development Console Output Format
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+const (
+	consoleTimeLayout   string = "15:04:05.000" // consoleTimeLayout is the time layout of the console format
+	consoleLevelWidth   int    = 4              // consoleLevelWidth is the width of the level column
+	consoleCallerWidth  int    = 24             // consoleCallerWidth is the width of the caller column
+	consoleMessageWidth int    = 40             // consoleMessageWidth is the width of the message column
+)
+
+// consoleFormatter encodes log messages in aligned columns for people reading a terminal
+type consoleFormatter struct {
+	colorOutput bool // colorOutput selects coloring the level and the field keys
+}
+
+// NewConsoleFormatter returns a formatter writing the time, level, caller and message in aligned columns
+// Nested field values are pretty printed on the following lines, and colorOutput colors the level and the keys
+func NewConsoleFormatter(colorOutput bool) Formatter {
+	return &consoleFormatter{colorOutput: colorOutput}
+}
+
+// Development configures the log instance for local development
+// Debug messages and callers are written to the terminal in the console format,
+// colored when the standard output supports colors
+func Development() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logLevel.Store(int64(LevelDebug))
+		logInstance.terminalOutput = true
+		logInstance.fileOutput = false
+		logInstance.colorOutput = false
+		logInstance.captureCaller = true
+		logInstance.logFormatter = NewConsoleFormatter(colorSupported(os.Stdout))
+	}
+}
+
+// Production configures the log instance for production use
+// Normal messages and above are written to the file as compact JSON lines with UTC times
+func Production() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logLevel.Store(int64(LevelNormal))
+		logInstance.terminalOutput = false
+		logInstance.fileOutput = true
+		logInstance.colorOutput = false
+		logInstance.utcTime = true
+		logInstance.outputFormat = FormatJSON
+		logInstance.logFormatter = nil
+	}
+}
+
+// Format writes the log message in aligned columns
+func (logConsole *consoleFormatter) Format(logEntry Entry) ([]byte, error) {
+	var lineBuilder strings.Builder
+
+	lineBuilder.WriteString(logEntry.Time.Format(consoleTimeLayout) + " ")
+
+	messageType := logEntry.internalType()
+	levelLabel := strings.Trim(logEntry.instance().levelLabel(messageType), " []")
+
+	levelColor := lookupLevel(messageType).levelColor
+
+	if logEntry.logInstance != nil {
+		levelColor = logEntry.logInstance.levelColor(messageType, levelColor)
+	}
+
+	lineBuilder.WriteString(logConsole.colored(levelColor, padColumn(levelLabel, consoleLevelWidth)))
+	lineBuilder.WriteByte(' ')
+
+	if logEntry.Caller != nil {
+		entryCaller := callerInformation{callerFile: logEntry.Caller.File, callerLine: logEntry.Caller.Line}
+		lineBuilder.WriteString(padColumn(entryCaller.shortFile(), consoleCallerWidth) + " ")
+	}
+
+	if len(logEntry.Fields) == 0 {
+		lineBuilder.WriteString(logEntry.Message)
+	} else {
+		lineBuilder.WriteString(padColumn(logEntry.Message, consoleMessageWidth))
+	}
+
+	for _, entryField := range logEntry.Fields {
+		fieldValue := fieldText(entryField)
+
+		var indentedValue bytes.Buffer
+
+		if (strings.HasPrefix(fieldValue, "{") || strings.HasPrefix(fieldValue, "[")) &&
+			json.Indent(&indentedValue, []byte(fieldValue), "    ", "  ") == nil && strings.Contains(indentedValue.String(), "\n") {
+			fieldValue = "\n    " + indentedValue.String()
+		}
+
+		lineBuilder.WriteString(" " + logConsole.colored(ColorCyan, entryField.Key+"=") + fieldValue)
+	}
+
+	if logEntry.Stack != "" {
+		lineBuilder.WriteString("\n" + logEntry.Stack)
+	}
+
+	return []byte(lineBuilder.String()), nil
+}
+
+// colored wraps the text in the color code when colors are enabled
+func (logConsole *consoleFormatter) colored(colorCode string, coloredText string) string {
+	if !logConsole.colorOutput || colorCode == ColorDefault {
+		return coloredText
+	}
+
+	return degradeColor(colorCode) + coloredText + ColorDefault
+}
+
+// padColumn pads the text with spaces to the column width
+func padColumn(columnText string, columnWidth int) string {
+	if len(columnText) >= columnWidth {
+		return columnText
+	}
+
+	return columnText + strings.Repeat(" ", columnWidth-len(columnText))
+}
@@ -0,0 +1,92 @@
+// Context-Aware Logging
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "context"
+
+// contextKey is the type of the context key holding the log fields
+type contextKey struct{}
+
+// ContextWithFields returns a copy of the context carrying the fields
+// The fields are added to the ones already stored in the context
+func ContextWithFields(parentContext context.Context, logFields ...Field) context.Context {
+	parentFields := FieldsFromContext(parentContext)
+	contextFields := append(make([]Field, 0, len(parentFields)+len(logFields)), parentFields...)
+
+	return context.WithValue(parentContext, contextKey{}, append(contextFields, logFields...))
+}
+
+// FieldsFromContext returns the fields stored in the context
+func FieldsFromContext(parentContext context.Context) []Field {
+	if parentContext == nil {
+		return nil
+	}
+
+	contextFields, _ := parentContext.Value(contextKey{}).([]Field)
+
+	return contextFields
+}
+
+// InfoCtx logs a message with the fields of the context to the configured destinations with normal formatting
+func (logInstance *LogInstance) InfoCtx(parentContext context.Context, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageNormal, jsonContent, withContext(parentContext, messageContent)...)
+}
+
+// WarnCtx logs a message with the fields of the context to the configured destinations with warning formatting
+func (logInstance *LogInstance) WarnCtx(parentContext context.Context, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageWarning, jsonContent, withContext(parentContext, messageContent)...)
+}
+
+// ErrorCtx logs a message with the fields of the context to the configured destinations with error formatting
+func (logInstance *LogInstance) ErrorCtx(parentContext context.Context, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageError, jsonContent, withContext(parentContext, messageContent)...)
+}
+
+// FatalCtx logs a message with the fields of the context to the configured destinations with fatal formatting and exits
+func (logInstance *LogInstance) FatalCtx(parentContext context.Context, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, messageFatal, jsonContent, withContext(parentContext, messageContent)...)
+}
+
+// PanicCtx logs a message with the fields of the context to the configured destinations with panic formatting and panics
+func (logInstance *LogInstance) PanicCtx(parentContext context.Context, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessagePanic, jsonContent, withContext(parentContext, messageContent)...)
+}
+
+// DebugCtx logs a message with the fields of the context to the configured destinations with debug formatting
+func (logInstance *LogInstance) DebugCtx(parentContext context.Context, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageDebug, jsonContent, withContext(parentContext, messageContent)...)
+}
+
+// TraceCtx logs a message with the fields of the context to the configured destinations with trace formatting
+func (logInstance *LogInstance) TraceCtx(parentContext context.Context, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageTrace, jsonContent, withContext(parentContext, messageContent)...)
+}
+
+// contextFields returns the fields stored in the context followed by the identifiers of its active span
+func contextFields(parentContext context.Context) []Field {
+	storedFields := FieldsFromContext(parentContext)
+	spanFields := traceFields(parentContext)
+
+	if len(spanFields) == 0 {
+		return storedFields
+	}
+
+	return append(append(make([]Field, 0, len(storedFields)+len(spanFields)), storedFields...), spanFields...)
+}
+
+// withContext returns the message content preceded by the fields of the context
+func withContext(parentContext context.Context, messageContent []interface{}) []interface{} {
+	messageFields := contextFields(parentContext)
+
+	if len(messageFields) == 0 {
+		return messageContent
+	}
+
+	return append([]interface{}{messageFields}, messageContent...)
+}
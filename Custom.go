@@ -0,0 +1,24 @@
+// Custom Level Message Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// Custom logs a message to the terminal with the formatting of a registered level
+func (logInstance *LogInstance) Custom(messageType string, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, false, true, false, messageType, jsonContent, messageContent...)
+}
+
+// CustomC logs a message to the terminal with the colored formatting of a registered level
+func (logInstance *LogInstance) CustomC(messageType string, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, false, true, true, messageType, jsonContent, messageContent...)
+}
+
+// FCustom logs a message of a registered level to the log file
+func (logInstance *LogInstance) FCustom(messageType string, jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, true, false, false, messageType, jsonContent, messageContent...)
+}
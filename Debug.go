@@ -0,0 +1,24 @@
+// Debug Message Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// Debug logs a message to the configured destinations with debug formatting
+func (logInstance *LogInstance) Debug(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageDebug, jsonContent, messageContent...)
+}
+
+// DebugC logs a message to the terminal with debug formatting
+func (logInstance *LogInstance) DebugC(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, false, true, true, MessageDebug, jsonContent, messageContent...)
+}
+
+// FDebug logs a debug message to the log file
+func (logInstance *LogInstance) FDebug(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, true, false, false, MessageDebug, jsonContent, messageContent...)
+}
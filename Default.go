@@ -0,0 +1,86 @@
+// Default Log Instance
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// defaultInstance holds the log instance used by the package level functions
+var defaultInstance atomic.Pointer[LogInstance]
+
+func init() {
+	defaultInstance.Store(InitializeWriter(nil, WithDebugSelector(os.Getenv(environmentPrefix+"DEBUG"))))
+}
+
+// SetDefault sets the log instance used by the package level functions
+// If logInstance is nil, the default terminal only log instance is restored, with the GOLOG_DEBUG selector applied
+func SetDefault(logInstance *LogInstance) {
+	if logInstance == nil {
+		logInstance = InitializeWriter(nil, WithDebugSelector(os.Getenv(environmentPrefix+"DEBUG")))
+	}
+
+	defaultInstance.Store(logInstance)
+}
+
+// Default returns the log instance used by the package level functions
+func Default() *LogInstance {
+	return defaultInstance.Load()
+}
+
+// Info logs a message with normal formatting using the default log instance
+func Info(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	Default().Info(jsonContent, messageContent...)
+}
+
+// Warn logs a message with warning formatting using the default log instance
+func Warn(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	Default().Warn(jsonContent, messageContent...)
+}
+
+// Error logs a message with error formatting using the default log instance
+func Error(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	Default().Error(jsonContent, messageContent...)
+}
+
+// Fatal logs a message with fatal formatting using the default log instance and exits
+func Fatal(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	Default().Fatal(jsonContent, messageContent...)
+}
+
+// Debug logs a message with debug formatting using the default log instance
+func Debug(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	Default().Debug(jsonContent, messageContent...)
+}
+
+// Trace logs a message with trace formatting using the default log instance
+func Trace(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	Default().Trace(jsonContent, messageContent...)
+}
+
+// Named returns the child logger of the default log instance registered under the name
+func Named(loggerName string) *LogInstance {
+	return Default().Named(loggerName)
+}
+
+// SetLevelFor sets the level of the named loggers of the default log instance matching the pattern
+func SetLevelFor(namePattern string, logLevel Level) {
+	Default().SetLevelFor(namePattern, logLevel)
+}
+
+// IsLevelEnabled reports whether the default log instance would write a message of the severity
+func IsLevelEnabled(logLevel Level) bool {
+	return Default().IsLevelEnabled(logLevel)
+}
+
+// DebugEnabled reports whether the default log instance would write debug messages
+func DebugEnabled() bool {
+	return Default().DebugEnabled()
+}
@@ -0,0 +1,116 @@
+// Additional Output Destinations
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "fmt"
+
+// Destination is an additional output receiving every log message written by a log instance
+// Destinations are created with constructors such as NewSyslogDestination and attached with WithDestination
+// Applications write destinations of their own by implementing WriteEntry and Close, and Flush when they buffer their output
+type Destination interface {
+	WriteEntry(logEntry Entry) error // WriteEntry writes a single log message
+	Close() error                    // Close flushes pending output and releases the destination
+}
+
+// entryWriter is implemented by the built-in destinations, which write the log message entry without exporting it first
+type entryWriter interface {
+	writeEntry(logInstance *LogInstance, messageEntry logEntry) error // writeEntry writes a single log message entry
+}
+
+// WithDestination writes every log message to the destination in addition to the file and the terminal
+// The destination is closed along with the log instance
+func WithDestination(logDestination Destination) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logOutput.logDestinations = append(logInstance.logOutput.logDestinations, logDestination)
+	}
+}
+
+// writeDestinations writes the log message entry to every additional destination
+// Destinations failing to write the entry are reported like a failed file write
+func writeDestinations(logInstance *LogInstance, messageEntry logEntry) {
+	for _, logDestination := range logInstance.logOutput.logDestinations {
+		if writeError := writeDestination(logInstance, logDestination, messageEntry); writeError != nil {
+			reportWriteError(logInstance, writeError, messageEntry, formatEntry(logInstance, FormatText, messageEntry))
+		}
+	}
+}
+
+// writeDestination writes the log message entry to the destination
+// Destinations outside this package receive it as an Entry through WriteEntry
+func writeDestination(logInstance *LogInstance, logDestination Destination, messageEntry logEntry) error {
+	if builtinDestination, isBuiltin := logDestination.(entryWriter); isBuiltin {
+		return builtinDestination.writeEntry(logInstance, messageEntry)
+	}
+
+	return logDestination.WriteEntry(exportEntry(logInstance, messageEntry))
+}
+
+// flushDestinations flushes every additional destination that buffers its output
+func flushDestinations(logInstance *LogInstance) error {
+	var flushError error
+
+	for _, logDestination := range logInstance.logOutput.logDestinations {
+		if bufferedDestination, isBuffered := logDestination.(flushWriter); isBuffered {
+			if destinationError := bufferedDestination.Flush(); destinationError != nil && flushError == nil {
+				flushError = destinationError
+			}
+		}
+	}
+
+	return flushError
+}
+
+// closeDestinations closes every additional destination
+func closeDestinations(logInstance *LogInstance) error {
+	var closeError error
+
+	for _, logDestination := range logInstance.logOutput.logDestinations {
+		if destinationError := logDestination.Close(); destinationError != nil && closeError == nil {
+			closeError = destinationError
+		}
+	}
+
+	logInstance.logOutput.logDestinations = nil
+
+	return closeError
+}
+
+// entryText returns the caller, the message content and the fields of the log message entry as a single line
+// Destinations that record the time and the level themselves use it instead of the full text line
+func entryText(messageEntry logEntry) string {
+	return string(appendEntryText(nil, messageEntry))
+}
+
+// appendEntryText appends the text form of the caller, the message content and the fields to the line buffer
+func appendEntryText(lineBuffer []byte, messageEntry logEntry) []byte {
+	if messageEntry.entryCaller != nil {
+		lineBuffer = append(lineBuffer, messageEntry.entryCaller.shortFile()...)
+		lineBuffer = append(lineBuffer, ' ')
+		lineBuffer = append(lineBuffer, messageEntry.entryCaller.callerFunction...)
+		lineBuffer = append(lineBuffer, ' ')
+	}
+
+	lineBuffer = fmt.Append(lineBuffer, messageEntry.messageParts...)
+
+	if messageEntry.entryFields != nil {
+		lineBuffer = append(lineBuffer, " ["...)
+
+		for _, entryField := range messageEntry.entryFields {
+			lineBuffer = append(lineBuffer, " ("...)
+			lineBuffer = append(lineBuffer, entryField.Key...)
+			lineBuffer = append(lineBuffer, ": "...)
+			lineBuffer = appendFieldText(lineBuffer, entryField)
+			lineBuffer = append(lineBuffer, ')')
+		}
+
+		lineBuffer = append(lineBuffer, " ]"...)
+	}
+
+	return lineBuffer
+}
@@ -0,0 +1,72 @@
+// Internal Diagnostics
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	DiagnosticRotation string = "rotation" // DiagnosticRotation reports failures to rotate, compress or prune log files
+	DiagnosticReopen   string = "reopen"   // DiagnosticReopen reports failures to reopen the log file on a signal
+	DiagnosticReload   string = "reload"   // DiagnosticReload reports configuration files that could not be applied
+	DiagnosticNetwork  string = "network"  // DiagnosticNetwork reports lost, failed and restored collector connections
+	DiagnosticQueue    string = "queue"    // DiagnosticQueue reports messages dropped by a full asynchronous queue
+	DiagnosticHook     string = "hook"     // DiagnosticHook reports fatal hooks that panicked
+	DiagnosticDelivery string = "delivery" // DiagnosticDelivery reports batches a background delivery could not send
+)
+
+// Diagnostic is an operational problem of the logging pipeline itself, such as a failed rotation
+type Diagnostic struct {
+	Time      time.Time // Time is the time the problem was noticed
+	Component string    // Component is the part of the pipeline reporting the problem, such as DiagnosticRotation
+	Message   string    // Message describes the problem
+	Error     error     // Error is the underlying error, nil when there is none
+}
+
+// WithDiagnostics hands the operational problems of the logging pipeline to diagnosticHandler,
+// so operators can see when logging itself is unhealthy, instead of writing them to the standard error stream
+// Rotation failures, collector connections lost and restored, messages dropped by a full asynchronous queue,
+// failed reloads and reopens, panicking fatal hooks and batches the HTTP based destinations and hooks could not
+// deliver in the background are reported
+// The handler may run on background goroutines and while the log instance lock is held, so it must not
+// log through the same log instance
+func WithDiagnostics(diagnosticHandler func(Diagnostic)) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.diagnosticHandler = diagnosticHandler
+	}
+}
+
+// String returns the diagnostic in the form it is written to the standard error stream without a handler
+func (logDiagnostic Diagnostic) String() string {
+	if logDiagnostic.Error == nil {
+		return logDiagnostic.Message
+	}
+
+	return logDiagnostic.Message + " because " + logDiagnostic.Error.Error()
+}
+
+// diagnose reports an operational problem to the diagnostic handler, or to the standard error stream without one
+func (logInstance *LogInstance) diagnose(diagnosticComponent string, diagnosticMessage string, diagnosticError error) {
+	logDiagnostic := Diagnostic{
+		Time:      logInstance.logClock(),
+		Component: diagnosticComponent,
+		Message:   diagnosticMessage,
+		Error:     diagnosticError,
+	}
+
+	if logInstance.diagnosticHandler != nil {
+		logInstance.diagnosticHandler(logDiagnostic)
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, logDiagnostic.String())
+}
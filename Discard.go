@@ -0,0 +1,26 @@
+// Discarding Log Instance
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"io"
+	"math"
+)
+
+// Discard returns a log instance dropping every message before it is formatted
+// It suits benchmarks, tests and libraries that need a non-nil logger by default
+// Fatal messages still run the exit behavior and panic messages still panic
+func Discard() *LogInstance {
+	logInstance := newInstance()
+	logInstance.logLevel.Store(math.MaxInt)
+	logInstance.terminalOutput = false
+	logInstance.logOutput.logWriter = io.Discard
+
+	return logInstance
+}
@@ -0,0 +1,118 @@
+// Elasticsearch Bulk Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// errBulkRejected is returned when Elasticsearch rejected some documents of a bulk request
+var errBulkRejected = errors.New("elasticsearch rejected some log messages of the bulk request")
+
+// ElasticsearchDestination indexes log messages through the _bulk API of Elasticsearch or OpenSearch
+// Messages are encoded in the JSON format of the log instance and delivered in batches from the background
+type ElasticsearchDestination struct {
+	bulkURL      string       // bulkURL is the address of the _bulk API
+	indexPattern string       // indexPattern is the name of the index with an optional date layout in braces
+	httpSettings HTTPSettings // httpSettings configures the requests
+	logBatch     *batchSender // logBatch collects and delivers the encoded messages
+}
+
+// elasticsearchItem is a log message waiting to be indexed
+type elasticsearchItem struct {
+	indexName   string // indexName is the index receiving the message
+	messageLine string // messageLine is the JSON encoded message
+}
+
+// NewElasticsearchDestination returns a destination indexing log messages through the _bulk API at bulkURL
+// A time layout between braces in indexPattern is replaced with the UTC date of the message,
+// for example "logs-{2006.01.02}" writes to a daily index such as logs-2024.05.01
+func NewElasticsearchDestination(bulkURL string, indexPattern string, httpSettings HTTPSettings) *ElasticsearchDestination {
+	elasticsearchDestination := &ElasticsearchDestination{
+		bulkURL:      bulkURL,
+		indexPattern: indexPattern,
+		httpSettings: httpSettings,
+	}
+
+	elasticsearchDestination.logBatch = newBatchSender(httpSettings.BatchSettings, elasticsearchDestination.deliver)
+
+	return elasticsearchDestination
+}
+
+// WriteEntry encodes the log message and queues it for indexing
+func (elasticsearchDestination *ElasticsearchDestination) WriteEntry(logEntry Entry) error {
+	return elasticsearchDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message and queues it for indexing
+func (elasticsearchDestination *ElasticsearchDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	elasticsearchDestination.logBatch.add(logInstance, elasticsearchItem{
+		indexName:   indexName(elasticsearchDestination.indexPattern, messageEntry.entryTime),
+		messageLine: encodeJSON(jsonTime(logInstance, messageEntry.entryTime), messageEntry),
+	})
+
+	return nil
+}
+
+// Flush delivers every pending message
+func (elasticsearchDestination *ElasticsearchDestination) Flush() error {
+	return elasticsearchDestination.logBatch.Flush()
+}
+
+// Close delivers every pending message and stops the background delivery
+func (elasticsearchDestination *ElasticsearchDestination) Close() error {
+	return elasticsearchDestination.logBatch.Close()
+}
+
+// deliver sends the batch as a single bulk request and reports rejected documents
+func (elasticsearchDestination *ElasticsearchDestination) deliver(batchItems []interface{}) error {
+	var requestBody []byte
+
+	for _, batchItem := range batchItems {
+		pendingItem := batchItem.(elasticsearchItem)
+
+		requestBody = append(requestBody, `{"index":{`...)
+		requestBody = appendJSONPair(requestBody, "_index", pendingItem.indexName)
+		requestBody = append(requestBody, "}}\n"...)
+		requestBody = append(requestBody, pendingItem.messageLine+"\n"...)
+	}
+
+	responseBody, postError := postBatch(elasticsearchDestination.bulkURL, "application/x-ndjson",
+		requestBody, elasticsearchDestination.httpSettings)
+
+	if postError != nil {
+		return postError
+	}
+
+	var bulkResponse struct {
+		Errors bool `json:"errors"` // Errors reports whether any document was rejected
+	}
+
+	if json.Unmarshal(responseBody, &bulkResponse) == nil && bulkResponse.Errors {
+		return errBulkRejected
+	}
+
+	return nil
+}
+
+// indexName replaces the time layout between braces in the index pattern with the UTC message time
+func indexName(indexPattern string, messageTime time.Time) string {
+	layoutStart := strings.IndexByte(indexPattern, '{')
+	layoutEnd := strings.LastIndexByte(indexPattern, '}')
+
+	if layoutStart < 0 || layoutEnd < layoutStart {
+		return indexPattern
+	}
+
+	return indexPattern[:layoutStart] + messageTime.UTC().Format(indexPattern[layoutStart+1:layoutEnd]) +
+		indexPattern[layoutEnd+1:]
+}
@@ -0,0 +1,131 @@
+// At-Rest Log Encryption
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// maximumRecordSize limits the size of an encrypted record the decryption accepts
+const maximumRecordSize int = 64 << 20
+
+// errEncryptionKey is returned by every write of a log instance configured with an invalid encryption key
+var errEncryptionKey = errors.New("the encryption key is invalid, the file output is discarded")
+
+// WithEncryption encrypts the file output with AES-GCM using encryptionKey of 16, 24 or 32 bytes
+// The file holds a sequence of records that DecryptLog turns back into the plain log
+// InitializeE returns the error of an invalid key, other constructors discard the file output
+// so plain text never reaches the disk, and rotated files stay encrypted
+func WithEncryption(encryptionKey []byte) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.encryptionKey = append([]byte{}, encryptionKey...)
+	}
+}
+
+// DecryptLog writes the plain log held by the records of encryptedLog to plainLog
+// It stops with an error at the first record that is truncated or fails authentication
+func DecryptLog(encryptedLog io.Reader, encryptionKey []byte, plainLog io.Writer) error {
+	logCipher, cipherError := newLogCipher(encryptionKey)
+
+	if cipherError != nil {
+		return cipherError
+	}
+
+	recordReader := bufio.NewReader(encryptedLog)
+	recordHeader := make([]byte, 4)
+
+	for {
+		if _, readError := io.ReadFull(recordReader, recordHeader); readError != nil {
+			if readError == io.EOF {
+				return nil
+			}
+
+			return readError
+		}
+
+		recordSize := int(binary.BigEndian.Uint32(recordHeader))
+
+		if recordSize < logCipher.NonceSize()+logCipher.Overhead() || recordSize > maximumRecordSize {
+			return errors.New("the encrypted log holds a record of an invalid size")
+		}
+
+		sealedRecord := make([]byte, recordSize)
+
+		if _, readError := io.ReadFull(recordReader, sealedRecord); readError != nil {
+			return readError
+		}
+
+		nonceSize := logCipher.NonceSize()
+		plainLines, openError := logCipher.Open(nil, sealedRecord[:nonceSize], sealedRecord[nonceSize:], nil)
+
+		if openError != nil {
+			return openError
+		}
+
+		if _, writeError := plainLog.Write(plainLines); writeError != nil {
+			return writeError
+		}
+	}
+}
+
+// newLogCipher returns the AES-GCM cipher of the key
+func newLogCipher(encryptionKey []byte) (cipher.AEAD, error) {
+	blockCipher, cipherError := aes.NewCipher(encryptionKey)
+
+	if cipherError != nil {
+		return nil, cipherError
+	}
+
+	return cipher.NewGCM(blockCipher)
+}
+
+// encryptOutput wraps the file output in an encrypting writer when encryption is enabled
+// With an invalid key, it returns the error of the key along with a writer refusing every write
+func (logInstance *LogInstance) encryptOutput(logWriter io.Writer) (io.Writer, error) {
+	if logInstance.encryptionKey == nil || logWriter == io.Discard {
+		return logWriter, nil
+	}
+
+	logCipher, cipherError := newLogCipher(logInstance.encryptionKey)
+
+	if cipherError != nil {
+		return &lineWriter{targetWriter: logWriter, writeFailure: errEncryptionKey}, cipherError
+	}
+
+	return &lineWriter{
+		targetWriter: logWriter,
+		writeLines: func(targetWriter io.Writer, plainLines []byte) error {
+			return sealLines(targetWriter, logCipher, plainLines)
+		},
+	}, nil
+}
+
+// sealLines writes the plain lines as a single encrypted record
+// Every record is the big endian length of the rest, a random nonce and the sealed lines with their tag
+func sealLines(targetWriter io.Writer, logCipher cipher.AEAD, plainLines []byte) error {
+	nonceSize := logCipher.NonceSize()
+	sealedRecord := make([]byte, 4+nonceSize, 4+nonceSize+len(plainLines)+logCipher.Overhead())
+
+	if _, randomError := rand.Read(sealedRecord[4:]); randomError != nil {
+		return randomError
+	}
+
+	sealedRecord = logCipher.Seal(sealedRecord, sealedRecord[4:], plainLines, nil)
+	binary.BigEndian.PutUint32(sealedRecord, uint32(len(sealedRecord)-4))
+
+	_, writeError := targetWriter.Write(sealedRecord)
+
+	return writeError
+}
@@ -0,0 +1,145 @@
+// Log Entry Representation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"time"
+)
+
+// logEntry holds everything known about a log message once it passed the level filter
+type logEntry struct {
+	entryTime    time.Time          // entryTime is the time the message was logged
+	messageType  string             // messageType is the message identifier
+	messageLevel levelDefinition    // messageLevel is the definition of the message level
+	entryFields  []Field            // entryFields are the collected structured fields
+	messageParts []interface{}      // messageParts is the message content without the typed fields
+	entryCaller  *callerInformation // entryCaller is the source location of the log call when captured
+	entryStack   string             // entryStack is the stack trace of the log call when captured
+}
+
+// Entry is a log message handed to a Formatter or a Hook
+// It holds the message once the level filter passed, with the content rendered as text
+// An Entry built by an application only needs Time, Level, Message, Fields and Caller to be encoded
+// by a built-in format, the level name and message type are looked up from the severity
+//
+// The built-in encodings of an Entry are a stable contract checked against golden files:
+// FormatJSON writes one object with the keys time, level, caller, function and message, followed by
+// the fields in their collected order and the stack, and fields named like one of these keys are
+// prefixed with fields. so they never replace it
+// FormatLogfmt writes the same pairs with the keys ts and msg for the time and the message, and
+// FormatText writes the time, the level label, the caller, the message and the fields in brackets
+type Entry struct {
+	Time        time.Time // Time is the time the message was logged
+	Level       Level     // Level is the severity of the message
+	LevelName   string    // LevelName is the human readable name of the level, such as info
+	MessageType string    // MessageType is the message identifier, such as MessageNormal, MessageFatal with LevelFatal for fatal messages
+	Message     string    // Message is the message content without the typed fields
+	Fields      []Field   // Fields are the collected structured fields in their collected order
+	Caller      *Caller   // Caller is the source location of the log call when captured
+	Stack       string    // Stack is the stack trace of the log call when captured
+
+	logInstance *LogInstance // logInstance is the log instance whose time settings the built-in formats use
+}
+
+// Caller is the source location of a log call
+type Caller struct {
+	File     string // File is the path of the source file
+	Line     int    // Line is the line number in the source file
+	Function string // Function is the fully qualified function name
+}
+
+// exportEntry returns the log message entry as an Entry for a formatter
+func exportEntry(logInstance *LogInstance, messageEntry logEntry) Entry {
+	exportedEntry := Entry{
+		Time:        messageEntry.entryTime,
+		Level:       messageEntry.messageLevel.levelSeverity,
+		LevelName:   messageEntry.messageLevel.levelName,
+		MessageType: exportedType(messageEntry.messageType),
+		Message:     fmt.Sprint(messageEntry.messageParts...),
+		Fields:      messageEntry.entryFields,
+		Stack:       messageEntry.entryStack,
+		logInstance: logInstance,
+	}
+
+	if messageEntry.entryCaller != nil {
+		exportedEntry.Caller = &Caller{
+			File:     messageEntry.entryCaller.callerFile,
+			Line:     messageEntry.entryCaller.callerLine,
+			Function: messageEntry.entryCaller.callerFunction,
+		}
+	}
+
+	return exportedEntry
+}
+
+// instance returns the log instance that wrote the message, or the formatter defaults for an Entry built by an application
+func (exportedEntry Entry) instance() *LogInstance {
+	if exportedEntry.logInstance == nil {
+		return formatterDefaults
+	}
+
+	return exportedEntry.logInstance
+}
+
+// exportedType returns the message identifier an Entry carries for the internal one, MessageFatal for fatal messages
+func exportedType(messageType string) string {
+	if messageType == messageFatal {
+		return MessageFatal
+	}
+
+	return messageType
+}
+
+// internalType returns the internal message identifier of the Entry, telling fatal messages apart from
+// error messages by their level
+func (exportedEntry Entry) internalType() string {
+	if exportedEntry.MessageType == MessageFatal && exportedEntry.Level == LevelFatal {
+		return messageFatal
+	}
+
+	return exportedEntry.MessageType
+}
+
+// internalEntry returns the Entry as a log message entry for the built-in formats
+// The level definition is looked up from the message type and its severity is taken from Level
+// Without a message type, the level registered with the severity of Level is used
+func (exportedEntry Entry) internalEntry() logEntry {
+	exportedEntry.MessageType = exportedEntry.internalType()
+	messageLevel := lookupLevel(exportedEntry.MessageType)
+
+	if exportedEntry.MessageType == "" {
+		exportedEntry.MessageType, messageLevel = lookupSeverity(exportedEntry.Level)
+	}
+
+	messageLevel.levelSeverity = exportedEntry.Level
+
+	if exportedEntry.LevelName != "" {
+		messageLevel.levelName = exportedEntry.LevelName
+	}
+
+	messageEntry := logEntry{
+		entryTime:    exportedEntry.Time,
+		messageType:  exportedEntry.MessageType,
+		messageLevel: messageLevel,
+		entryFields:  exportedEntry.Fields,
+		messageParts: []interface{}{exportedEntry.Message},
+		entryStack:   exportedEntry.Stack,
+	}
+
+	if exportedEntry.Caller != nil {
+		messageEntry.entryCaller = &callerInformation{
+			callerFile:     exportedEntry.Caller.File,
+			callerLine:     exportedEntry.Caller.Line,
+			callerFunction: exportedEntry.Caller.Function,
+		}
+	}
+
+	return messageEntry
+}
@@ -0,0 +1,101 @@
+// Configuration from Environment Variables
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// environmentPrefix starts the name of every environment variable read by FromEnv
+const environmentPrefix string = "GOLOG_"
+
+// FromEnv returns a log instance configured by the GOLOG_ environment variables
+// See ConfigFromEnv for the variables, settings without a variable keep their defaults
+func FromEnv() (*LogInstance, error) {
+	logConfig, environmentError := ConfigFromEnv(Config{})
+
+	if environmentError != nil {
+		return nil, environmentError
+	}
+
+	return FromConfig(logConfig)
+}
+
+// ConfigFromEnv returns the configuration with the settings of the GOLOG_ environment variables applied
+// Every setting of Config is read from GOLOG_ followed by its key in upper case, such as GOLOG_LEVEL,
+// GOLOG_FORMAT, GOLOG_FILE, GOLOG_COLOR or GOLOG_DEBUG, and rotation settings from GOLOG_ROTATION_, such as GOLOG_ROTATION_MAX_SIZE
+// Fields and destinations are only read from configuration files
+func ConfigFromEnv(logConfig Config) (Config, error) {
+	environmentError := applyEnvironment(reflect.ValueOf(&logConfig).Elem(), environmentPrefix)
+
+	return logConfig, environmentError
+}
+
+// applyEnvironment sets the fields of the configuration structure from the environment variables starting with namePrefix
+func applyEnvironment(configValue reflect.Value, namePrefix string) error {
+	configType := configValue.Type()
+
+	for fieldIndex := 0; fieldIndex < configType.NumField(); fieldIndex++ {
+		configKey := strings.Split(configType.Field(fieldIndex).Tag.Get("json"), ",")[0]
+		variableName := namePrefix + strings.ToUpper(configKey)
+		fieldValue := configValue.Field(fieldIndex)
+
+		if fieldValue.Kind() == reflect.Struct {
+			if environmentError := applyEnvironment(fieldValue, variableName+"_"); environmentError != nil {
+				return environmentError
+			}
+
+			continue
+		}
+
+		variableValue, isSet := os.LookupEnv(variableName)
+
+		if !isSet {
+			continue
+		}
+
+		switch fieldValue.Kind() {
+		case reflect.String:
+			fieldValue.SetString(variableValue)
+
+		case reflect.Bool:
+			boolValue, parseError := strconv.ParseBool(variableValue)
+
+			if parseError != nil {
+				return fmt.Errorf("environment variable %s %q is not a boolean", variableName, variableValue)
+			}
+
+			fieldValue.SetBool(boolValue)
+
+		case reflect.Int, reflect.Int64:
+			integerValue, parseError := strconv.ParseInt(variableValue, 10, 64)
+
+			if parseError != nil {
+				return fmt.Errorf("environment variable %s %q is not an integer", variableName, variableValue)
+			}
+
+			fieldValue.SetInt(integerValue)
+
+		case reflect.Pointer:
+			boolValue, parseError := strconv.ParseBool(variableValue)
+
+			if parseError != nil {
+				return fmt.Errorf("environment variable %s %q is not a boolean", variableName, variableValue)
+			}
+
+			fieldValue.Set(reflect.ValueOf(&boolValue))
+		}
+	}
+
+	return nil
+}
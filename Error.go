@@ -0,0 +1,25 @@
+// Error Message Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// Error logs a message to the configured destinations with error formatting
+// Unlike Fatal, it returns to the caller after logging
+func (logInstance *LogInstance) Error(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageError, jsonContent, messageContent...)
+}
+
+// ErrorC logs a message to the terminal with error formatting
+func (logInstance *LogInstance) ErrorC(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, false, true, true, MessageError, jsonContent, messageContent...)
+}
+
+// FError logs an error message to the log file
+func (logInstance *LogInstance) FError(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, true, false, false, MessageError, jsonContent, messageContent...)
+}
@@ -0,0 +1,82 @@
+// Windows Event Log Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build windows
+
+package GoLog
+
+import "golang.org/x/sys/windows/svc/eventlog"
+
+// eventIdentifier is the event identifier of every message written to the event log
+const eventIdentifier uint32 = 1
+
+// EventLogDestination writes log messages to the Windows Event Log
+// Messages below the minimum level are left out so the event log only receives severe messages
+type EventLogDestination struct {
+	eventLog     *eventlog.Log // eventLog is the handle of the registered event source
+	minimumLevel Level         // minimumLevel is the minimum severity a message needs to be written
+}
+
+// InstallEventSource registers eventSource in the registry so the event viewer can display its messages
+// It requires administrative rights and is usually run once while installing a service
+func InstallEventSource(eventSource string) error {
+	return eventlog.InstallAsEventCreate(eventSource, eventlog.Error|eventlog.Warning|eventlog.Info)
+}
+
+// RemoveEventSource deletes the registration of eventSource from the registry
+func RemoveEventSource(eventSource string) error {
+	return eventlog.Remove(eventSource)
+}
+
+// NewEventLogDestination opens the event log of the registered eventSource
+// Messages at or above minimumLevel are written, for example LevelWarning for warnings, errors and fatal messages
+func NewEventLogDestination(eventSource string, minimumLevel Level) (*EventLogDestination, error) {
+	eventLog, openError := eventlog.Open(eventSource)
+
+	if openError != nil {
+		return nil, openError
+	}
+
+	return &EventLogDestination{eventLog: eventLog, minimumLevel: minimumLevel}, nil
+}
+
+// WriteEntry writes the log message as an event of the matching type
+func (eventLogDestination *EventLogDestination) WriteEntry(logEntry Entry) error {
+	return eventLogDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry writes the log message as an event of the matching type
+func (eventLogDestination *EventLogDestination) writeEntry(_ *LogInstance, messageEntry logEntry) error {
+	messageSeverity := messageEntry.messageLevel.levelSeverity
+
+	if messageSeverity < eventLogDestination.minimumLevel {
+		return nil
+	}
+
+	messageText := entryText(messageEntry)
+
+	if messageEntry.entryStack != "" {
+		messageText += "\n" + messageEntry.entryStack
+	}
+
+	switch {
+	case messageSeverity >= LevelError:
+		return eventLogDestination.eventLog.Error(eventIdentifier, messageText)
+
+	case messageSeverity >= LevelWarning:
+		return eventLogDestination.eventLog.Warning(eventIdentifier, messageText)
+
+	default:
+		return eventLogDestination.eventLog.Info(eventIdentifier, messageText)
+	}
+}
+
+// Close closes the handle of the event source
+func (eventLogDestination *EventLogDestination) Close() error {
+	return eventLogDestination.eventLog.Close()
+}
@@ -0,0 +1,73 @@
+// Fatal Exit Behavior
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"os"
+)
+
+// WithExitCode sets the status code the process exits with after a fatal message
+func WithExitCode(exitCode int) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.exitCode = exitCode
+	}
+}
+
+// WithExitFunction replaces os.Exit with exitFunction after a fatal message
+// The function receives the configured exit code
+func WithExitFunction(exitFunction func(exitCode int)) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.exitFunction = exitFunction
+	}
+}
+
+// WithoutExit keeps the process running after a fatal message
+// Fatal then returns to the caller like Error
+func WithoutExit() Option {
+	return WithExitFunction(func(int) {})
+}
+
+// OnFatal registers a function that runs after a fatal message is written and before the process exits
+// Functions run one after another in registration order and a panicking function does not stop the exit
+func (logInstance *LogInstance) OnFatal(fatalHook func()) {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	logInstance.fatalHooks = append(logInstance.fatalHooks, fatalHook)
+}
+
+// exitProcess runs the fatal hooks and the configured exit behavior after a fatal message
+func (logInstance *LogInstance) exitProcess() {
+	logInstance.logLock.Lock()
+	fatalHooks := logInstance.fatalHooks
+	logInstance.logLock.Unlock()
+
+	for _, fatalHook := range fatalHooks {
+		runFatalHook(logInstance, fatalHook)
+	}
+
+	if logInstance.exitFunction != nil {
+		logInstance.exitFunction(logInstance.exitCode)
+		return
+	}
+
+	os.Exit(logInstance.exitCode)
+}
+
+// runFatalHook runs a fatal hook and recovers from its panic
+func runFatalHook(logInstance *LogInstance, fatalHook func()) {
+	defer func() {
+		if panicValue := recover(); panicValue != nil {
+			logInstance.diagnose(DiagnosticHook, fmt.Sprint("fatal hook panicked with ", panicValue), nil)
+		}
+	}()
+
+	fatalHook()
+}
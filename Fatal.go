@@ -8,17 +8,36 @@
 
 package GoLog
 
-// Fatal logs a message to the terminal with fatal formatting
+// WithFatalLabel writes fatal messages with the [ FATA ] tag instead of [ ERRO ], so they can be told apart
+// from error messages in the text output
+// This changes the text output, parsers matching fatal messages by the [ ERRO ] tag need to be updated
+func WithFatalLabel() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.fatalLabel = true
+	}
+}
+
+// levelLabel returns the level tag the message type is written with in the text output
+// Fatal messages keep the tag of MessageFatal they had before the error level existed, so existing parsers keep working
+func (logInstance *LogInstance) levelLabel(messageType string) string {
+	if messageType == messageFatal && !logInstance.fatalLabel {
+		return MessageFatal
+	}
+
+	return messageType
+}
+
+// Fatal logs a message to the configured destinations with fatal formatting
 func (logInstance *LogInstance) Fatal(jsonContent map[string]interface{}, messageContent ...interface{}) {
-	printOutPut(logInstance, false, true, false, MessageFatal, jsonContent, messageContent...)
+	printConfigured(logInstance, messageFatal, jsonContent, messageContent...)
 }
 
 // FatalC logs a message to the terminal with fatal formatting
 func (logInstance *LogInstance) FatalC(jsonContent map[string]interface{}, messageContent ...interface{}) {
-	printOutPut(logInstance, false, true, true, MessageFatal, jsonContent, messageContent...)
+	printOutPut(logInstance, false, true, true, messageFatal, jsonContent, messageContent...)
 }
 
 // FFatal logs a fatal message to the log file
 func (logInstance *LogInstance) FFatal(jsonContent map[string]interface{}, messageContent ...interface{}) {
-	printOutPut(logInstance, true, false, false, MessageFatal, jsonContent, messageContent...)
+	printOutPut(logInstance, true, false, false, messageFatal, jsonContent, messageContent...)
 }
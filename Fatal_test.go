@@ -0,0 +1,53 @@
+// Fatal Log Generation Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFatalMessageType(t *testing.T) {
+	if MessageFatal != " [ ERRO ] " {
+		t.Fatalf("MessageFatal is %q, want the [ ERRO ] identifier", MessageFatal)
+	}
+
+	for _, testCase := range []struct {
+		caseName      string   // caseName names the subtest
+		logOptions    []Option // logOptions configure the log instance
+		expectedLabel string   // expectedLabel is the level tag of the written fatal message
+	}{
+		{caseName: "default", expectedLabel: "[ ERRO ]"},
+		{caseName: "fatal label", logOptions: []Option{WithFatalLabel()}, expectedLabel: "[ FATA ]"},
+	} {
+		t.Run(testCase.caseName, func(t *testing.T) {
+			var fileOutput bytes.Buffer
+			var hookedEntry Entry
+
+			logOptions := append([]Option{WithFile(true), WithTerminal(false), WithoutExit()}, testCase.logOptions...)
+			logInstance := InitializeWriter(&fileOutput, logOptions...)
+			logInstance.AddHook(HookFunc(func(hookEntry *Entry) bool {
+				hookedEntry = *hookEntry
+				return true
+			}))
+
+			logInstance.Fatal(nil, "fatal message")
+
+			if hookedEntry.MessageType != MessageFatal || hookedEntry.Level != LevelFatal || hookedEntry.LevelName != "fatal" {
+				t.Errorf("hooked entry has type %q, level %d and name %q, want MessageFatal, LevelFatal and fatal",
+					hookedEntry.MessageType, hookedEntry.Level, hookedEntry.LevelName)
+			}
+
+			if !strings.Contains(fileOutput.String(), testCase.expectedLabel+" fatal message") {
+				t.Errorf("file output %q misses the %s tag", fileOutput.String(), testCase.expectedLabel)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Typed Field Constructors
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"math"
+	"time"
+)
+
+// fieldType represents the kind of value held by a field
+type fieldType int
+
+const (
+	fieldAny      fieldType = iota // fieldAny represents a value of any type
+	fieldString                    // fieldString represents a string value
+	fieldInteger                   // fieldInteger represents a signed integer value
+	fieldFloat                     // fieldFloat represents a floating point value
+	fieldBoolean                   // fieldBoolean represents a boolean value
+	fieldDuration                  // fieldDuration represents a time duration value
+	fieldTime                      // fieldTime represents a time value
+	fieldError                     // fieldError represents an error value
+)
+
+// Field is a typed key and value pair attached to a log message
+// Fields can be passed along with the message content of every logging method
+// With the text format, a log call allocates only the slices collecting its message content and fields,
+// typed values are encoded and the line is written without allocation
+type Field struct {
+	Key string // Key is the name of the field
+
+	valueType      fieldType   // valueType is the kind of value held by the field
+	integerValue   int64       // integerValue holds integer, boolean, duration and float values
+	stringValue    string      // stringValue holds string values
+	interfaceValue interface{} // interfaceValue holds time, error and untyped values
+}
+
+// String constructs a field holding a string value
+func String(fieldKey string, fieldValue string) Field {
+	return Field{Key: fieldKey, valueType: fieldString, stringValue: fieldValue}
+}
+
+// Int constructs a field holding an integer value
+func Int(fieldKey string, fieldValue int) Field {
+	return Field{Key: fieldKey, valueType: fieldInteger, integerValue: int64(fieldValue)}
+}
+
+// Int64 constructs a field holding a 64 bit integer value
+func Int64(fieldKey string, fieldValue int64) Field {
+	return Field{Key: fieldKey, valueType: fieldInteger, integerValue: fieldValue}
+}
+
+// Float64 constructs a field holding a floating point value
+func Float64(fieldKey string, fieldValue float64) Field {
+	return Field{Key: fieldKey, valueType: fieldFloat, integerValue: int64(math.Float64bits(fieldValue))}
+}
+
+// Bool constructs a field holding a boolean value
+func Bool(fieldKey string, fieldValue bool) Field {
+	var integerValue int64
+
+	if fieldValue {
+		integerValue = 1
+	}
+
+	return Field{Key: fieldKey, valueType: fieldBoolean, integerValue: integerValue}
+}
+
+// Duration constructs a field holding a time duration value
+func Duration(fieldKey string, fieldValue time.Duration) Field {
+	return Field{Key: fieldKey, valueType: fieldDuration, integerValue: int64(fieldValue)}
+}
+
+// Time constructs a field holding a time value
+func Time(fieldKey string, fieldValue time.Time) Field {
+	return Field{Key: fieldKey, valueType: fieldTime, interfaceValue: fieldValue}
+}
+
+// Err constructs a field named error holding the error value
+func Err(fieldValue error) Field {
+	return Field{Key: "error", valueType: fieldError, interfaceValue: fieldValue}
+}
+
+// Any constructs a field holding a value of any type
+func Any(fieldKey string, fieldValue interface{}) Field {
+	return Field{Key: fieldKey, valueType: fieldAny, interfaceValue: fieldValue}
+}
+
+// Value returns the value held by the field
+func (logField Field) Value() interface{} {
+	switch logField.valueType {
+	case fieldString:
+		return logField.stringValue
+
+	case fieldInteger:
+		return logField.integerValue
+
+	case fieldFloat:
+		return math.Float64frombits(uint64(logField.integerValue))
+
+	case fieldBoolean:
+		return logField.integerValue == 1
+
+	case fieldDuration:
+		return time.Duration(logField.integerValue)
+
+	case fieldError:
+		if logField.interfaceValue == nil {
+			return nil
+		}
+
+		return logField.interfaceValue.(error).Error()
+	}
+
+	return logField.interfaceValue
+}
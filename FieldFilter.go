@@ -0,0 +1,112 @@
+// Field Allowlists and Denylists
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// FieldFilter selects the fields an output receives
+// Filters are created with AllowFields and DenyFields
+type FieldFilter struct {
+	fieldKeys map[string]bool // fieldKeys holds the listed field keys
+	allowList bool            // allowList selects keeping only the listed fields instead of removing them
+}
+
+// filterDestination removes fields from the log messages before forwarding them to another destination
+type filterDestination struct {
+	targetDestination Destination // targetDestination receives the filtered messages
+	fieldFilter       FieldFilter // fieldFilter selects the forwarded fields
+}
+
+// AllowFields returns a filter keeping only the fields named in fieldKeys
+func AllowFields(fieldKeys ...string) FieldFilter {
+	return FieldFilter{fieldKeys: fieldKeySet(fieldKeys), allowList: true}
+}
+
+// DenyFields returns a filter removing the fields named in fieldKeys
+func DenyFields(fieldKeys ...string) FieldFilter {
+	return FieldFilter{fieldKeys: fieldKeySet(fieldKeys)}
+}
+
+// WithFileFields applies the filter to the fields of the file output, network output included
+// For example WithFileFields(DenyFields("request_body", "authorization")) keeps these fields
+// on the terminal for debugging while they never reach the file
+func WithFileFields(fieldFilter FieldFilter) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.fileFilter = &fieldFilter
+	}
+}
+
+// WithTerminalFields applies the filter to the fields of the terminal output
+func WithTerminalFields(fieldFilter FieldFilter) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.terminalFilter = &fieldFilter
+	}
+}
+
+// FilterFields returns a destination forwarding the log messages to logDestination with the fields the filter keeps
+func FilterFields(logDestination Destination, fieldFilter FieldFilter) Destination {
+	return &filterDestination{targetDestination: logDestination, fieldFilter: fieldFilter}
+}
+
+// WriteEntry forwards the log message with the filtered fields
+func (logFilter *filterDestination) WriteEntry(logEntry Entry) error {
+	return logFilter.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry forwards the log message with the filtered fields
+func (logFilter *filterDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	return writeDestination(logInstance, logFilter.targetDestination, logFilter.fieldFilter.apply(messageEntry))
+}
+
+// Flush flushes the target destination when it buffers its output
+func (logFilter *filterDestination) Flush() error {
+	if bufferedDestination, isBuffered := logFilter.targetDestination.(flushWriter); isBuffered {
+		return bufferedDestination.Flush()
+	}
+
+	return nil
+}
+
+// Close closes the target destination
+func (logFilter *filterDestination) Close() error {
+	return logFilter.targetDestination.Close()
+}
+
+// apply returns the log message entry with the fields the filter keeps
+// A nil filter keeps every field
+func (fieldFilter *FieldFilter) apply(messageEntry logEntry) logEntry {
+	if fieldFilter == nil || len(messageEntry.entryFields) == 0 {
+		return messageEntry
+	}
+
+	keptFields := make([]Field, 0, len(messageEntry.entryFields))
+
+	for _, entryField := range messageEntry.entryFields {
+		if fieldFilter.fieldKeys[entryField.Key] == fieldFilter.allowList {
+			keptFields = append(keptFields, entryField)
+		}
+	}
+
+	if len(keptFields) == 0 {
+		keptFields = nil
+	}
+
+	messageEntry.entryFields = keptFields
+
+	return messageEntry
+}
+
+// fieldKeySet returns the field keys as a set
+func fieldKeySet(fieldKeys []string) map[string]bool {
+	keySet := make(map[string]bool, len(fieldKeys))
+
+	for _, fieldKey := range fieldKeys {
+		keySet[fieldKey] = true
+	}
+
+	return keySet
+}
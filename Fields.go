@@ -0,0 +1,195 @@
+// Structured Fields
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// sortedKeys returns the keys of the structured content in ascending order
+// Sorting keeps the output stable between runs
+func sortedKeys(jsonContent map[string]interface{}) []string {
+	jsonKeys := make([]string, 0, len(jsonContent))
+
+	for jsonKey := range jsonContent {
+		jsonKeys = append(jsonKeys, jsonKey)
+	}
+
+	sort.Strings(jsonKeys)
+
+	return jsonKeys
+}
+
+// collectFields gathers the bound fields, the structured content and the typed fields of a log message
+// Bound fields come first, then map entries sorted by key, followed by the typed fields in call order
+// The remaining message content is returned without the typed fields
+func collectFields(boundFields []Field, jsonContent map[string]interface{},
+	messageContent []interface{}) ([]Field, []interface{}) {
+	// Count the typed fields first so the fields and the message content are allocated once
+
+	typedCount, partCount := 0, 0
+
+	for _, messagePart := range messageContent {
+		switch typedPart := messagePart.(type) {
+		case Field:
+			typedCount++
+
+		case []Field:
+			typedCount += len(typedPart)
+
+		default:
+			partCount++
+		}
+	}
+
+	var entryFields []Field
+
+	if boundFields != nil || jsonContent != nil || typedCount > 0 {
+		entryFields = append(make([]Field, 0, len(boundFields)+len(jsonContent)+typedCount), boundFields...)
+	}
+
+	if jsonContent != nil {
+		for _, jsonKey := range sortedKeys(jsonContent) {
+			entryFields = append(entryFields, Any(jsonKey, jsonContent[jsonKey]))
+		}
+	}
+
+	var messageParts []interface{}
+
+	if partCount > 0 {
+		messageParts = make([]interface{}, 0, partCount)
+	}
+
+	for _, messagePart := range messageContent {
+		switch typedPart := messagePart.(type) {
+		case Field:
+			entryFields = append(entryFields, typedPart)
+
+		case []Field:
+			entryFields = append(entryFields, typedPart...)
+
+		default:
+			messageParts = append(messageParts, messagePart)
+		}
+	}
+
+	return entryFields, messageParts
+}
+
+// fieldText returns the text form of a field value
+func fieldText(logField Field) string {
+	switch logField.valueType {
+	case fieldString:
+		return logField.stringValue
+
+	case fieldAny, fieldTime:
+		return formatFieldValue(logField.interfaceValue)
+	}
+
+	return string(appendFieldText(nil, logField))
+}
+
+// maximumNestingDepth limits how deep nested structured content is normalized
+const maximumNestingDepth int = 32
+
+// normalizeValue converts nested structured content into values encoding/json can serialize
+// Errors become their message, maps with non string keys get text keys and
+// anything nested deeper than maximumNestingDepth falls back to its text form
+func normalizeValue(fieldValue interface{}, nestingDepth int) interface{} {
+	if fieldValue == nil {
+		return nil
+	}
+
+	if nestingDepth > maximumNestingDepth {
+		return fmt.Sprint(fieldValue)
+	}
+
+	switch typedValue := fieldValue.(type) {
+	case Field:
+		return normalizeValue(typedValue.Value(), nestingDepth)
+
+	case error:
+		return typedValue.Error()
+
+	case time.Duration:
+		return typedValue.String()
+
+	case json.Marshaler, encoding.TextMarshaler:
+		return typedValue
+	}
+
+	reflectValue := reflect.ValueOf(fieldValue)
+
+	switch reflectValue.Kind() {
+	case reflect.Map:
+		normalizedMap := make(map[string]interface{}, reflectValue.Len())
+		mapIterator := reflectValue.MapRange()
+
+		for mapIterator.Next() {
+			normalizedMap[fmt.Sprint(mapIterator.Key().Interface())] =
+				normalizeValue(mapIterator.Value().Interface(), nestingDepth+1)
+		}
+
+		return normalizedMap
+
+	case reflect.Slice, reflect.Array:
+		if reflectValue.Kind() == reflect.Slice && reflectValue.Type().Elem().Kind() == reflect.Uint8 {
+			return fieldValue
+		}
+
+		normalizedSlice := make([]interface{}, reflectValue.Len())
+
+		for sliceIndex := range normalizedSlice {
+			normalizedSlice[sliceIndex] = normalizeValue(reflectValue.Index(sliceIndex).Interface(), nestingDepth+1)
+		}
+
+		return normalizedSlice
+
+	case reflect.Pointer, reflect.Interface:
+		if reflectValue.IsNil() {
+			return nil
+		}
+
+		return normalizeValue(reflectValue.Elem().Interface(), nestingDepth+1)
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return fmt.Sprint(fieldValue)
+	}
+
+	return fieldValue
+}
+
+// formatFieldValue returns the text form of a field value
+// Nested maps, slices and structs are written as JSON, everything else keeps its default format
+func formatFieldValue(fieldValue interface{}) string {
+	switch fieldValue.(type) {
+	case nil, error, fmt.Stringer:
+		return fmt.Sprint(fieldValue)
+	}
+
+	reflectValue := reflect.ValueOf(fieldValue)
+
+	for reflectValue.Kind() == reflect.Pointer && !reflectValue.IsNil() {
+		reflectValue = reflectValue.Elem()
+	}
+
+	switch reflectValue.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		if encodedValue, encodeError := json.Marshal(normalizeValue(fieldValue, 0)); encodeError == nil {
+			return string(encodedValue)
+		}
+	}
+
+	return fmt.Sprint(fieldValue)
+}
@@ -0,0 +1,107 @@
+// Output Formats
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format represents the encoding used for every log message
+type Format int
+
+const (
+	FormatText   Format = iota // FormatText represents the human readable text format
+	FormatJSON                 // FormatJSON represents one JSON object per line
+	FormatLogfmt               // FormatLogfmt represents one line of logfmt key and value pairs per message
+)
+
+// WithFormat sets the format used to encode every log message
+func WithFormat(outputFormat Format) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.outputFormat = outputFormat
+	}
+}
+
+// parseFormat returns the built-in format of the name: text, json or logfmt
+func parseFormat(formatName string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(formatName)) {
+	case "text":
+		return FormatText, nil
+
+	case "json":
+		return FormatJSON, nil
+
+	case "logfmt":
+		return FormatLogfmt, nil
+
+	default:
+		return FormatText, fmt.Errorf("format %q is not text, json or logfmt", formatName)
+	}
+}
+
+// formatEntry encodes the log message entry as a single line in the selected format
+// The line carries neither color codes nor the trailing newline
+func formatEntry(logInstance *LogInstance, outputFormat Format, messageEntry logEntry) string {
+	switch outputFormat {
+	case FormatJSON:
+		return encodeJSON(jsonTime(logInstance, messageEntry.entryTime), messageEntry)
+
+	case FormatLogfmt:
+		return encodeLogfmt(jsonTime(logInstance, messageEntry.entryTime), messageEntry)
+
+	default:
+		return string(appendTextLine(logInstance, appendTimestamp(logInstance, nil, messageEntry.entryTime), messageEntry))
+	}
+}
+
+// WithFileFormat encodes the file output with logFormatter, whatever format the terminal output uses
+// For example WithFileFormat(FormatJSON) writes JSON lines to the file while the terminal keeps the colored text
+func WithFileFormat(logFormatter Formatter) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.fileFormatter = logFormatter
+	}
+}
+
+// WithTerminalFormat encodes the terminal output with logFormatter, whatever format the file output uses
+func WithTerminalFormat(logFormatter Formatter) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.terminalFormatter = logFormatter
+	}
+}
+
+// selectFormatter returns the formatter of an output
+// An output formatter takes precedence over the formatter and the format of the log instance
+func (logInstance *LogInstance) selectFormatter(outputFormatter Formatter) Formatter {
+	if outputFormatter != nil {
+		return outputFormatter
+	}
+
+	if logInstance.logFormatter != nil {
+		return logInstance.logFormatter
+	}
+
+	return logInstance.outputFormat
+}
+
+// printFormatted writes the log message with the formatter to the specified output destinations
+// The text format keeps its own writer so its colors and field layout stay unchanged
+func printFormatted(logInstance *LogInstance, logFormatter Formatter, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool, messageEntry logEntry) {
+	if !needFileOutput && !needTerminalOutput {
+		return
+	}
+
+	if logFormatter == FormatText {
+		printText(logInstance, needFileOutput, needTerminalOutput, needTerminalColoredOutput, messageEntry)
+		return
+	}
+
+	printLine(logInstance, logFormatter, needFileOutput, needTerminalOutput, needTerminalColoredOutput, messageEntry)
+}
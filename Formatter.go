@@ -0,0 +1,48 @@
+// Custom Output Formatters
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// Formatter encodes a log message as a single line
+// The built-in formats implement it, so FormatJSON can be passed wherever a Formatter is expected
+type Formatter interface {
+	Format(logEntry Entry) ([]byte, error) // Format returns the encoded message without the trailing newline
+}
+
+// formatterDefaults provides the default time settings to built-in formats used outside a log instance
+var formatterDefaults = newInstance()
+
+// WithFormatter encodes every file and terminal message with the formatter instead of the selected format
+// Messages the formatter fails to encode are written in the text format
+func WithFormatter(logFormatter Formatter) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logFormatter = logFormatter
+	}
+}
+
+// Format encodes the log message in the built-in format
+// The time settings of the log instance that wrote the message are used
+func (outputFormat Format) Format(logEntry Entry) ([]byte, error) {
+	return []byte(formatEntry(logEntry.instance(), outputFormat, logEntry.internalEntry())), nil
+}
+
+// encodeEntry encodes the log message entry with the formatter
+// If the formatter fails, the message is encoded in the text format
+func encodeEntry(logInstance *LogInstance, logFormatter Formatter, messageEntry logEntry) string {
+	if builtinFormat, isBuiltin := logFormatter.(Format); isBuiltin {
+		return formatEntry(logInstance, builtinFormat, messageEntry)
+	}
+
+	encodedLine, formatError := logFormatter.Format(exportEntry(logInstance, messageEntry))
+
+	if formatError != nil {
+		return formatEntry(logInstance, FormatText, messageEntry)
+	}
+
+	return string(encodedLine)
+}
@@ -0,0 +1,219 @@
+// Graylog Extended Log Format Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gelfChunkSize    int = 8192 // gelfChunkSize is the maximum size of a single datagram
+	gelfChunkHeader  int = 12   // gelfChunkHeader is the size of the header of every chunk
+	gelfMaximumChunk int = 128  // gelfMaximumChunk is the maximum number of chunks of a message
+)
+
+// errGELFTooLarge is returned when a message needs more chunks than GELF allows
+var errGELFTooLarge = errors.New("the message is too large for a GELF datagram")
+
+// GELFDestination sends log messages to Graylog in the Graylog Extended Log Format
+// UDP messages are compressed and split into chunks when needed, TCP messages are delimited by a null byte
+type GELFDestination struct {
+	gelfLock          sync.Mutex // gelfLock guards the connection
+	networkType       string     // networkType is either udp or tcp
+	networkAddress    string     // networkAddress is the address of the GELF input
+	networkConnection net.Conn   // networkConnection is the open connection, nil after a failed TCP write
+	hostName          string     // hostName is written as the host of every message
+}
+
+// NewGELFDestination connects to the GELF input of Graylog at networkAddress over networkType
+// networkType is udp or tcp, failed TCP connections are reopened on the next message
+func NewGELFDestination(networkType string, networkAddress string) (*GELFDestination, error) {
+	networkConnection, dialError := net.DialTimeout(networkType, networkAddress, defaultNetworkTimeout)
+
+	if dialError != nil {
+		return nil, dialError
+	}
+
+	hostName, _ := os.Hostname()
+
+	return &GELFDestination{
+		networkType:       networkType,
+		networkAddress:    networkAddress,
+		networkConnection: networkConnection,
+		hostName:          hostName,
+	}, nil
+}
+
+// WriteEntry encodes the log message as a GELF object and sends it
+func (gelfDestination *GELFDestination) WriteEntry(logEntry Entry) error {
+	return gelfDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message as a GELF object and sends it
+func (gelfDestination *GELFDestination) writeEntry(_ *LogInstance, messageEntry logEntry) error {
+	gelfMessage := []byte(encodeGELF(gelfDestination.hostName, messageEntry))
+
+	gelfDestination.gelfLock.Lock()
+	defer gelfDestination.gelfLock.Unlock()
+
+	if strings.HasPrefix(gelfDestination.networkType, "udp") {
+		return gelfDestination.sendDatagram(gelfMessage)
+	}
+
+	if gelfDestination.networkConnection == nil {
+		networkConnection, dialError := net.DialTimeout(gelfDestination.networkType,
+			gelfDestination.networkAddress, defaultNetworkTimeout)
+
+		if dialError != nil {
+			return dialError
+		}
+
+		gelfDestination.networkConnection = networkConnection
+	}
+
+	gelfDestination.networkConnection.SetWriteDeadline(time.Now().Add(defaultNetworkTimeout))
+
+	if _, writeError := gelfDestination.networkConnection.Write(append(gelfMessage, 0)); writeError != nil {
+		gelfDestination.networkConnection.Close()
+		gelfDestination.networkConnection = nil
+
+		return writeError
+	}
+
+	return nil
+}
+
+// Close closes the connection to the GELF input
+func (gelfDestination *GELFDestination) Close() error {
+	gelfDestination.gelfLock.Lock()
+	defer gelfDestination.gelfLock.Unlock()
+
+	if gelfDestination.networkConnection == nil {
+		return nil
+	}
+
+	closeError := gelfDestination.networkConnection.Close()
+	gelfDestination.networkConnection = nil
+
+	return closeError
+}
+
+// sendDatagram compresses the message and sends it as one datagram or as a sequence of chunks
+func (gelfDestination *GELFDestination) sendDatagram(gelfMessage []byte) error {
+	var compressedMessage bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&compressedMessage)
+	gzipWriter.Write(gelfMessage)
+	gzipWriter.Close()
+
+	datagramContent := compressedMessage.Bytes()
+
+	if len(datagramContent) <= gelfChunkSize {
+		_, writeError := gelfDestination.networkConnection.Write(datagramContent)
+		return writeError
+	}
+
+	chunkPayload := gelfChunkSize - gelfChunkHeader
+	chunkCount := (len(datagramContent) + chunkPayload - 1) / chunkPayload
+
+	if chunkCount > gelfMaximumChunk {
+		return errGELFTooLarge
+	}
+
+	messageIdentifier := make([]byte, 8)
+	rand.Read(messageIdentifier)
+
+	for chunkIndex := 0; chunkIndex < chunkCount; chunkIndex++ {
+		chunkEnd := (chunkIndex + 1) * chunkPayload
+
+		if chunkEnd > len(datagramContent) {
+			chunkEnd = len(datagramContent)
+		}
+
+		gelfChunk := append([]byte{0x1e, 0x0f}, messageIdentifier...)
+		gelfChunk = append(gelfChunk, byte(chunkIndex), byte(chunkCount))
+		gelfChunk = append(gelfChunk, datagramContent[chunkIndex*chunkPayload:chunkEnd]...)
+
+		if _, writeError := gelfDestination.networkConnection.Write(gelfChunk); writeError != nil {
+			return writeError
+		}
+	}
+
+	return nil
+}
+
+// encodeGELF encodes the log message as a GELF 1.1 object
+// Fields become additional fields prefixed with an underscore and the stack trace becomes the full message
+func encodeGELF(hostName string, messageEntry logEntry) string {
+	gelfBuffer := []byte{'{'}
+
+	gelfBuffer = appendJSONPair(gelfBuffer, "version", "1.1")
+	gelfBuffer = append(gelfBuffer, ',')
+	gelfBuffer = appendJSONPair(gelfBuffer, "host", hostName)
+	gelfBuffer = append(gelfBuffer, ',')
+	gelfBuffer = appendJSONPair(gelfBuffer, "short_message", fmt.Sprint(messageEntry.messageParts...))
+	gelfBuffer = append(gelfBuffer, `,"timestamp":`...)
+	gelfBuffer = strconv.AppendFloat(gelfBuffer, float64(messageEntry.entryTime.UnixNano())/float64(time.Second), 'f', 6, 64)
+	gelfBuffer = append(gelfBuffer, `,"level":`...)
+	gelfBuffer = strconv.AppendInt(gelfBuffer, int64(syslogSeverity(messageEntry.messageLevel.levelSeverity)), 10)
+	gelfBuffer = append(gelfBuffer, ',')
+	gelfBuffer = appendJSONPair(gelfBuffer, "_level_name", messageEntry.messageLevel.levelName)
+
+	if messageEntry.entryStack != "" {
+		gelfBuffer = append(gelfBuffer, ',')
+		gelfBuffer = appendJSONPair(gelfBuffer, "full_message", messageEntry.entryStack)
+	}
+
+	if messageEntry.entryCaller != nil {
+		gelfBuffer = append(gelfBuffer, ',')
+		gelfBuffer = appendJSONPair(gelfBuffer, "_file", messageEntry.entryCaller.callerFile)
+		gelfBuffer = append(gelfBuffer, `,"_line":`...)
+		gelfBuffer = strconv.AppendInt(gelfBuffer, int64(messageEntry.entryCaller.callerLine), 10)
+		gelfBuffer = append(gelfBuffer, ',')
+		gelfBuffer = appendJSONPair(gelfBuffer, "_function", messageEntry.entryCaller.callerFunction)
+	}
+
+	for _, entryField := range messageEntry.entryFields {
+		gelfBuffer = append(gelfBuffer, ',')
+		gelfBuffer = appendJSONField(gelfBuffer, gelfFieldName(entryField.Key), entryField)
+	}
+
+	return string(append(gelfBuffer, '}'))
+}
+
+// gelfFieldName converts a field key into a valid GELF additional field name
+// Characters other than letters, digits, underscores, dashes and dots are replaced with underscores
+func gelfFieldName(fieldKey string) string {
+	fieldName := []byte(fieldKey)
+
+	for characterIndex, fieldCharacter := range fieldName {
+		isLetter := (fieldCharacter >= 'a' && fieldCharacter <= 'z') || (fieldCharacter >= 'A' && fieldCharacter <= 'Z')
+		isDigit := fieldCharacter >= '0' && fieldCharacter <= '9'
+
+		if !isLetter && !isDigit && fieldCharacter != '_' && fieldCharacter != '-' && fieldCharacter != '.' {
+			fieldName[characterIndex] = '_'
+		}
+	}
+
+	if string(fieldName) == "id" {
+		return "_field_id"
+	}
+
+	return "_" + string(fieldName)
+}
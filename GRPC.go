@@ -0,0 +1,97 @@
+// gRPC Logger Adapter
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GRPCLogger satisfies the grpclog.LoggerV2 interface of gRPC on top of a log instance
+// Install it with grpclog.SetLoggerV2(GoLog.NewGRPCLogger(logInstance, 0))
+type GRPCLogger struct {
+	logInstance  *LogInstance // logInstance is the log instance receiving the messages
+	verboseLevel int          // verboseLevel is the highest verbosity level reported as enabled
+}
+
+// NewGRPCLogger returns a gRPC logger writing through the log instance
+// Verbose gRPC messages up to verboseLevel are reported as enabled
+func NewGRPCLogger(logInstance *LogInstance, verboseLevel int) *GRPCLogger {
+	return &GRPCLogger{logInstance: logInstance, verboseLevel: verboseLevel}
+}
+
+// Info logs the arguments with normal formatting
+func (grpcLogger *GRPCLogger) Info(messageArguments ...interface{}) {
+	grpcLogger.print(MessageNormal, fmt.Sprint(messageArguments...))
+}
+
+// Infoln logs the arguments separated by spaces with normal formatting
+func (grpcLogger *GRPCLogger) Infoln(messageArguments ...interface{}) {
+	grpcLogger.print(MessageNormal, fmt.Sprintln(messageArguments...))
+}
+
+// Infof formats the arguments according to the format specifier and logs them with normal formatting
+func (grpcLogger *GRPCLogger) Infof(messageFormat string, messageArguments ...interface{}) {
+	grpcLogger.print(MessageNormal, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Warning logs the arguments with warning formatting
+func (grpcLogger *GRPCLogger) Warning(messageArguments ...interface{}) {
+	grpcLogger.print(MessageWarning, fmt.Sprint(messageArguments...))
+}
+
+// Warningln logs the arguments separated by spaces with warning formatting
+func (grpcLogger *GRPCLogger) Warningln(messageArguments ...interface{}) {
+	grpcLogger.print(MessageWarning, fmt.Sprintln(messageArguments...))
+}
+
+// Warningf formats the arguments according to the format specifier and logs them with warning formatting
+func (grpcLogger *GRPCLogger) Warningf(messageFormat string, messageArguments ...interface{}) {
+	grpcLogger.print(MessageWarning, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Error logs the arguments with error formatting
+func (grpcLogger *GRPCLogger) Error(messageArguments ...interface{}) {
+	grpcLogger.print(MessageError, fmt.Sprint(messageArguments...))
+}
+
+// Errorln logs the arguments separated by spaces with error formatting
+func (grpcLogger *GRPCLogger) Errorln(messageArguments ...interface{}) {
+	grpcLogger.print(MessageError, fmt.Sprintln(messageArguments...))
+}
+
+// Errorf formats the arguments according to the format specifier and logs them with error formatting
+func (grpcLogger *GRPCLogger) Errorf(messageFormat string, messageArguments ...interface{}) {
+	grpcLogger.print(MessageError, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Fatal logs the arguments with fatal formatting and exits
+func (grpcLogger *GRPCLogger) Fatal(messageArguments ...interface{}) {
+	grpcLogger.print(messageFatal, fmt.Sprint(messageArguments...))
+}
+
+// Fatalln logs the arguments separated by spaces with fatal formatting and exits
+func (grpcLogger *GRPCLogger) Fatalln(messageArguments ...interface{}) {
+	grpcLogger.print(messageFatal, fmt.Sprintln(messageArguments...))
+}
+
+// Fatalf formats the arguments according to the format specifier, logs them with fatal formatting and exits
+func (grpcLogger *GRPCLogger) Fatalf(messageFormat string, messageArguments ...interface{}) {
+	grpcLogger.print(messageFatal, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// V reports whether verbose messages at the verbosity level are enabled
+func (grpcLogger *GRPCLogger) V(verboseLevel int) bool {
+	return verboseLevel <= grpcLogger.verboseLevel
+}
+
+// print logs the message text without its trailing newline to the configured destinations
+func (grpcLogger *GRPCLogger) print(messageType string, messageText string) {
+	printConfigured(grpcLogger.logInstance, messageType, nil, strings.TrimSuffix(messageText, "\n"))
+}
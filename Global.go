@@ -0,0 +1,49 @@
+// Global Static Fields
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "os"
+
+// WithGlobalFields attaches the fields of the map to every message of the log instance and its child loggers
+// The fields are sorted by key and come before the fields of every message
+func WithGlobalFields(globalFields map[string]interface{}) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.boundFields, _ = collectFields(logInstance.boundFields, globalFields, nil)
+	}
+}
+
+// WithHostname attaches the host name reported by the operating system as the hostname field
+func WithHostname() Option {
+	return func(logInstance *LogInstance) {
+		if hostName, hostError := os.Hostname(); hostError == nil {
+			logInstance.boundFields = append(logInstance.boundFields, String("hostname", hostName))
+		}
+	}
+}
+
+// WithPID attaches the process identifier as the pid field
+func WithPID() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.boundFields = append(logInstance.boundFields, Int("pid", os.Getpid()))
+	}
+}
+
+// WithService attaches the service name as the service field
+func WithService(serviceName string) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.boundFields = append(logInstance.boundFields, String("service", serviceName))
+	}
+}
+
+// WithVersion attaches the version of the running build as the version field
+func WithVersion(buildVersion string) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.boundFields = append(logInstance.boundFields, String("version", buildVersion))
+	}
+}
@@ -0,0 +1,141 @@
+// HTTP Batch Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPSettings configures the delivery of log messages to an HTTP endpoint
+// Zero values select the defaults
+type HTTPSettings struct {
+	Headers  map[string]string // Headers are added to every request, such as an Authorization header
+	Compress bool              // Compress selects gzip compression of the request body
+	Client   *http.Client      // Client sends the requests, a client with a 10 second timeout when nil
+
+	BatchSettings // BatchSettings configures how the messages are grouped into requests
+}
+
+// HTTPDestination posts log messages as JSON arrays to an HTTP endpoint
+// Messages are encoded in the JSON format of the log instance and delivered in batches from the background
+type HTTPDestination struct {
+	endpointURL  string       // endpointURL is the address receiving the requests
+	httpSettings HTTPSettings // httpSettings configures the requests
+	logBatch     *batchSender // logBatch collects and delivers the encoded messages
+}
+
+// NewHTTPDestination returns a destination posting log messages to endpointURL
+func NewHTTPDestination(endpointURL string, httpSettings HTTPSettings) *HTTPDestination {
+	httpDestination := &HTTPDestination{endpointURL: endpointURL, httpSettings: httpSettings}
+	httpDestination.logBatch = newBatchSender(httpSettings.BatchSettings, httpDestination.deliver)
+
+	return httpDestination
+}
+
+// WriteEntry encodes the log message and queues it for delivery
+func (httpDestination *HTTPDestination) WriteEntry(logEntry Entry) error {
+	return httpDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message and queues it for delivery
+func (httpDestination *HTTPDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	httpDestination.logBatch.add(logInstance, encodeJSON(jsonTime(logInstance, messageEntry.entryTime), messageEntry))
+
+	return nil
+}
+
+// Flush delivers every pending message
+func (httpDestination *HTTPDestination) Flush() error {
+	return httpDestination.logBatch.Flush()
+}
+
+// Close delivers every pending message and stops the background delivery
+func (httpDestination *HTTPDestination) Close() error {
+	return httpDestination.logBatch.Close()
+}
+
+// deliver posts the batch as a JSON array
+func (httpDestination *HTTPDestination) deliver(batchItems []interface{}) error {
+	requestBody := []byte{'['}
+
+	for itemIndex, batchItem := range batchItems {
+		if itemIndex > 0 {
+			requestBody = append(requestBody, ',')
+		}
+
+		requestBody = append(requestBody, batchItem.(string)...)
+	}
+
+	_, postError := postBatch(httpDestination.endpointURL, "application/json", append(requestBody, ']'), httpDestination.httpSettings)
+
+	return postError
+}
+
+// postBatch sends the request body to the endpoint with the headers and the compression of the settings
+// It returns the response body, network failures, rate limiting and server errors are reported as retryable
+func postBatch(endpointURL string, contentType string, requestBody []byte, httpSettings HTTPSettings) ([]byte, error) {
+	var bodyReader io.Reader = bytes.NewReader(requestBody)
+
+	if httpSettings.Compress {
+		var compressedBody bytes.Buffer
+
+		gzipWriter := gzip.NewWriter(&compressedBody)
+		gzipWriter.Write(requestBody)
+		gzipWriter.Close()
+
+		bodyReader = &compressedBody
+	}
+
+	httpRequest, requestError := http.NewRequest(http.MethodPost, endpointURL, bodyReader)
+
+	if requestError != nil {
+		return nil, requestError
+	}
+
+	httpRequest.Header.Set("Content-Type", contentType)
+
+	if httpSettings.Compress {
+		httpRequest.Header.Set("Content-Encoding", "gzip")
+	}
+
+	for headerName, headerValue := range httpSettings.Headers {
+		httpRequest.Header.Set(headerName, headerValue)
+	}
+
+	httpClient := httpSettings.Client
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultNetworkTimeout * 2}
+	}
+
+	httpResponse, responseError := httpClient.Do(httpRequest)
+
+	if responseError != nil {
+		return nil, retryableError{deliveryError: responseError}
+	}
+
+	responseBody, readError := io.ReadAll(httpResponse.Body)
+	httpResponse.Body.Close()
+
+	if httpResponse.StatusCode < 300 {
+		return responseBody, readError
+	}
+
+	statusError := fmt.Errorf("the log endpoint answered with status %d", httpResponse.StatusCode)
+
+	if httpResponse.StatusCode == http.StatusTooManyRequests || httpResponse.StatusCode >= 500 {
+		return nil, retryableError{deliveryError: statusError}
+	}
+
+	return nil, statusError
+}
@@ -0,0 +1,104 @@
+// Entry Hook Pipeline
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "reflect"
+
+// Hook is invoked with every log message before it is written
+// It can change the entry, hand it to another system or return false to drop it
+// Hooks run in the order they were added while the log instance lock is held,
+// so they must not log through the same log instance
+type Hook interface {
+	Fire(hookEntry *Entry) bool // Fire processes the entry and reports whether it is written
+}
+
+// HookFunc adapts an ordinary function to the Hook interface
+type HookFunc func(hookEntry *Entry) bool
+
+// Fire calls the function with the entry
+func (hookFunction HookFunc) Fire(hookEntry *Entry) bool {
+	return hookFunction(hookEntry)
+}
+
+// AddHook appends the hook to the hooks of the log instance
+// Child loggers created afterwards inherit the hook
+func (logInstance *LogInstance) AddHook(logHook Hook) {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	logInstance.logHooks = append(logInstance.logHooks[:len(logInstance.logHooks):len(logInstance.logHooks)], logHook)
+}
+
+// RemoveHook removes every occurrence of the hook from the hooks of the log instance
+// A HookFunc is matched by the function it holds
+func (logInstance *LogInstance) RemoveHook(logHook Hook) {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	keptHooks := make([]Hook, 0, len(logInstance.logHooks))
+
+	for _, addedHook := range logInstance.logHooks {
+		if !sameHook(addedHook, logHook) {
+			keptHooks = append(keptHooks, addedHook)
+		}
+	}
+
+	logInstance.logHooks = keptHooks
+}
+
+// runHooks hands the log message entry to every hook and reports whether it is written
+// The log instance lock must be held by the caller
+func runHooks(logInstance *LogInstance, messageEntry *logEntry) bool {
+	if len(logInstance.logHooks) == 0 {
+		return true
+	}
+
+	hookEntry := exportEntry(logInstance, *messageEntry)
+
+	for _, logHook := range logInstance.logHooks {
+		if !logHook.Fire(&hookEntry) {
+			return false
+		}
+	}
+
+	*messageEntry = hookEntry.internalEntry()
+
+	return true
+}
+
+// sameHook reports whether both hooks are the same without panicking on uncomparable hooks
+func sameHook(firstHook Hook, secondHook Hook) bool {
+	firstValue := reflect.ValueOf(firstHook)
+	secondValue := reflect.ValueOf(secondHook)
+
+	if firstValue.Type() != secondValue.Type() {
+		return false
+	}
+
+	if firstValue.Kind() == reflect.Func {
+		return firstValue.Pointer() == secondValue.Pointer()
+	}
+
+	return firstValue.Type().Comparable() && firstHook == secondHook
+}
+
+// flushHooks flushes every hook that buffers its output
+func flushHooks(logInstance *LogInstance) error {
+	var flushError error
+
+	for _, logHook := range logInstance.logHooks {
+		if bufferedHook, isBuffered := logHook.(flushWriter); isBuffered {
+			if hookError := bufferedHook.Flush(); hookError != nil && flushError == nil {
+				flushError = hookError
+			}
+		}
+	}
+
+	return flushError
+}
@@ -15,148 +15,486 @@ package GoLog
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // LogInstance is a struct that holds information about logging/
 type LogInstance struct {
 	LogDestination *os.File // LogDestination is the file where the log will be written/
+
+	logOutput    *outputState            // logOutput holds the file output and the destinations and is shared with child loggers
+	logLevel     *atomic.Int64           // logLevel is the minimum severity a message needs to be written and is shared with child loggers
+	logRing      *ringBuffer             // logRing keeps the latest messages below the selected level when enabled
+	logSampler   *messageSampler         // logSampler drops repeated identical messages when enabled
+	rateLimit    *rateLimiter            // rateLimit limits the messages of a child logger created with WithRateLimit
+	rateLimiters map[string]*rateLimiter // rateLimiters holds the limits by key and is shared with child loggers
+	logRepeat    *repeatFilter           // logRepeat collapses consecutive identical messages when enabled
+	appendOutput bool                    // appendOutput selects appending to the log file instead of truncating it
+
+	logRotation rotationSettings // logRotation holds the conditions that trigger a log file rotation
+
+	terminalOutput bool      // terminalOutput selects terminal output for the leveled methods
+	fileOutput     bool      // fileOutput selects file output for the leveled methods
+	colorOutput    bool      // colorOutput selects colored terminal output for the leveled methods
+	colorMode      ColorMode // colorMode selects when requested colors are written
+
+	levelColors map[string]string // levelColors replaces the color of the message types it holds
+	logTheme    *Theme            // logTheme colors the parts of colored text terminal messages when set
+	stderrLevel Level             // stderrLevel is the minimum severity a terminal message needs to go to the standard error
+
+	timeFormat    string           // timeFormat is the layout used to format the message time
+	utcTime       bool             // utcTime selects formatting the message time in UTC
+	timePrecision TimePrecision    // timePrecision is the fractional second precision of the default time format
+	unixUnit      time.Duration    // unixUnit is the resolution of Unix timestamps, zero formats the time instead
+	logClock      func() time.Time // logClock returns the time of every log message
+	outputFormat  Format           // outputFormat is the format used to encode every log message
+	logFormatter  Formatter        // logFormatter replaces the selected format when set
+
+	fileFormatter     Formatter // fileFormatter replaces the format of the file output when set
+	terminalFormatter Formatter // terminalFormatter replaces the format of the terminal output when set
+
+	fileFilter     *FieldFilter // fileFilter selects the fields of the file output when set
+	terminalFilter *FieldFilter // terminalFilter selects the fields of the terminal output when set
+
+	logLock     *sync.Mutex // logLock serializes the output of concurrent log calls and is shared with child loggers
+	boundFields []Field     // boundFields are attached to every log message of a child logger
+
+	bufferSize    int           // bufferSize is the size of the file output buffer
+	flushInterval time.Duration // flushInterval is the period between automatic buffer flushes
+
+	exitCode     int                // exitCode is the status code the process exits with after a fatal message
+	fatalLabel   bool               // fatalLabel writes fatal messages with their own level tag instead of the error tag
+	exitFunction func(exitCode int) // exitFunction replaces os.Exit after a fatal message when set
+	fatalHooks   []func()           // fatalHooks run after a fatal message and before the exit
+
+	captureCaller bool  // captureCaller selects recording the source location of every log call
+	callerSkip    int   // callerSkip is the number of additional frames skipped to find the caller
+	captureStack  bool  // captureStack selects attaching stack traces to severe messages
+	stackLevel    Level // stackLevel is the minimum severity a message needs to carry a stack trace
+
+	networkTimeout time.Duration // networkTimeout limits connecting to and writing to a network collector
+	spillPath      string        // spillPath is the file holding network output while the collector is unreachable
+
+	encryptionKey []byte // encryptionKey is the AES key encrypting the file output when set
+	chainKey      []byte // chainKey is the HMAC key of the hash chain closing every line of the file output when set
+
+	logAudit *auditTrail // logAudit receives the audit events, shared with child loggers
+
+	logRegistry *loggerRegistry // logRegistry holds the named loggers and their level overrides, shared with child loggers
+	loggerName  string          // loggerName is the dotted name of a logger created with Named
+	namedNode   *namedLogger    // namedNode is the registry entry of a logger created with Named
+
+	logHooks       []Hook          // logHooks process every message before it is written
+	redactionRules []RedactionRule // redactionRules select the sensitive values replaced before encoding
+
+	errorHandler   func(writeError error, failedEntry Entry) // errorHandler is called when writing a message fails
+	fallbackWriter io.Writer                                 // fallbackWriter receives the messages that could not be written
+
+	diagnosticHandler func(Diagnostic) // diagnosticHandler receives the operational problems of the logging pipeline
+
+	asyncSize      int            // asyncSize is the capacity of the asynchronous output queue
+	overflowPolicy OverflowPolicy // overflowPolicy selects what a full asynchronous queue does with a new message
+	droppedEntries *atomic.Uint64 // droppedEntries counts the messages discarded by the overflow policy
+	logStopped     *atomic.Bool   // logStopped reports whether Shutdown stopped the log instance and its children
+}
+
+// outputState holds where a log instance writes, shared with its child loggers so reconfiguring and closing
+// the log instance reach every logger derived from it
+type outputState struct {
+	logWriter       io.Writer     // logWriter is the writer that receives the file output
+	logPath         string        // logPath is the path of the log file
+	logDestinations []Destination // logDestinations are the additional outputs receiving every message
+	logConfig       *configState  // logConfig remembers the applied configuration when the instance was configured from one
+	logQueue        *taskQueue    // logQueue runs the output in the background when asynchronous logging is enabled
 }
 
 const (
 	ColorDefault string = "\x1b[0;0m"  // ColorDefault represents the ANSI escape sequence for resetting the text color to the default
 	ColorRed     string = "\x1b[31;1m" // ColorRed represents the ANSI escape sequence for setting text color to red
 	ColorYellow  string = "\x1b[33;1m" // ColorYellow represents the ANSI escape sequence for setting text color to yellow
+	ColorBlue    string = "\x1b[34;1m" // ColorBlue represents the ANSI escape sequence for setting text color to blue
+	ColorCyan    string = "\x1b[36;1m" // ColorCyan represents the ANSI escape sequence for setting text color to cyan
+	ColorGreen   string = "\x1b[32;1m" // ColorGreen represents the ANSI escape sequence for setting text color to green
+	ColorMagenta string = "\x1b[35;1m" // ColorMagenta represents the ANSI escape sequence for setting text color to magenta
+	ColorWhite   string = "\x1b[37;1m" // ColorWhite represents the ANSI escape sequence for setting text color to white
+	ColorBold    string = "\x1b[1m"    // ColorBold represents the ANSI escape sequence for bold text in the default color
+	ColorDim     string = "\x1b[2m"    // ColorDim represents the ANSI escape sequence for dimmed text in the default color
 )
 
 const (
 	MessageNormal  string = " [ INFO ] " // MessageNormal represents a normal message identifier
-	MessageFatal   string = " [ ERRO ] " // MessageFatal represents a fatal error message identifier
+	MessageError   string = " [ ERRO ] " // MessageError represents an error message identifier
+	MessagePanic   string = " [ PANC ] " // MessagePanic represents a panic message identifier
+	MessageFatal   string = " [ ERRO ] " // MessageFatal represents a fatal error message identifier, shared with error messages
 	MessageWarning string = " [ WARN ] " // MessageWarning represents a warning message identifier
+	MessageDebug   string = " [ DEBU ] " // MessageDebug represents a debug message identifier
+	MessageTrace   string = " [ TRAC ] " // MessageTrace represents a trace message identifier
 )
 
+// messageFatal identifies fatal messages internally, as their exported identifier is the one of error messages
+const messageFatal string = " [ FATA ] "
+
 // Initialize the log data with the provided file destination
 // It opens the file specified by fileDestination and prepares it for writing
-// If the file cannot be opened, it returns false along with an error message
-func Initialize(logDestination string) *LogInstance {
-	fileDescriptor, openError := os.Create(logDestination)
+// If the file cannot be opened, it prints the error message and exits
+func Initialize(logDestination string, logOptions ...Option) *LogInstance {
+	logInstance, initializeError := InitializeE(logDestination, logOptions...)
 
-	if openError != nil {
-		fmt.Println("unable to create the selected file because", openError)
+	if initializeError != nil {
+		fmt.Println("unable to create the selected file because", initializeError)
 		os.Exit(1)
 	}
 
-	return &LogInstance{LogDestination: fileDescriptor}
+	return logInstance
+}
+
+// InitializeE the log data with the provided file destination
+// It opens the file specified by fileDestination and prepares it for writing
+// If the file cannot be opened, it returns nil along with the open error
+func InitializeE(logDestination string, logOptions ...Option) (*LogInstance, error) {
+	logInstance := newInstance()
+	logInstance.logOutput.logPath = logDestination
+
+	for _, logOption := range logOptions {
+		logOption(logInstance)
+	}
+
+	if openError := logInstance.openFileOutput(); openError != nil {
+		return nil, openError
+	}
+
+	logInstance.startQueue()
+
+	return logInstance, nil
+}
+
+// InitializeWriter the log data with the provided writer destination
+// The file output is written to logWriter instead of a file on the filesystem
+// If logWriter is nil, the file output is discarded
+func InitializeWriter(logWriter io.Writer, logOptions ...Option) *LogInstance {
+	logInstance := newInstance()
+
+	for _, logOption := range logOptions {
+		logOption(logInstance)
+	}
+
+	if logWriter == nil {
+		logWriter = io.Discard
+	}
+
+	if fileDescriptor, isFile := logWriter.(*os.File); isFile {
+		logInstance.LogDestination = fileDescriptor
+	}
+
+	encryptedOutput, _ := logInstance.encryptOutput(logWriter)
+
+	logInstance.logOutput.logWriter = logInstance.bufferOutput(logInstance.chainOutput(encryptedOutput, ""))
+	logInstance.startQueue()
+
+	return logInstance
+}
+
+// openFileOutput opens the file at the log path as the file output
+// The file is wrapped for rotation and, when enabled, encryption, the hash chain and buffering
+func (logInstance *LogInstance) openFileOutput() error {
+	if logInstance.encryptionKey != nil {
+		if _, cipherError := newLogCipher(logInstance.encryptionKey); cipherError != nil {
+			return cipherError
+		}
+	}
+
+	fileDescriptor, openError := openFile(logInstance.logOutput.logPath, logInstance.appendOutput)
+
+	if openError != nil {
+		return openError
+	}
+
+	logInstance.logRotation.logClock = logInstance.logClock
+	logInstance.logRotation.reportDiagnostic = logInstance.diagnose
+
+	logRotate, rotateError := newRotateWriter(logInstance.logOutput.logPath, logInstance.appendOutput,
+		fileDescriptor, logInstance.logRotation)
+
+	if rotateError != nil {
+		fileDescriptor.Close()
+		return rotateError
+	}
+
+	encryptedOutput, _ := logInstance.encryptOutput(logRotate)
+	previousHash := ""
+
+	if logInstance.appendOutput && logInstance.encryptionKey == nil {
+		previousHash = lastChainHash(logInstance.logOutput.logPath)
+	}
+
+	logInstance.LogDestination = fileDescriptor
+	logInstance.logOutput.logWriter = logInstance.bufferOutput(logInstance.chainOutput(encryptedOutput, previousHash))
+
+	return nil
+}
+
+// newInstance returns a log instance with the default settings
+func newInstance() *LogInstance {
+	return &LogInstance{
+		logOutput:      &outputState{},
+		logLevel:       newSharedLevel(LevelTrace),
+		terminalOutput: true,
+		stderrLevel:    LevelWarning,
+		exitCode:       1,
+		logClock:       time.Now,
+		logLock:        &sync.Mutex{},
+		rateLimiters:   make(map[string]*rateLimiter),
+		logRegistry:    &loggerRegistry{namedLoggers: make(map[string]*namedLogger)},
+		droppedEntries: &atomic.Uint64{},
+		logStopped:     &atomic.Bool{},
+	}
+}
+
+// openFile opens the log file either in append mode or truncating it
+func openFile(logPath string, appendOutput bool) (*os.File, error) {
+	if appendOutput {
+		return os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	}
+
+	return os.Create(logPath)
 }
 
 // ReturnFile returns the file descriptor of the log message
+// If the log instance was initialized with a writer that is not a file, it returns nil
 func (logInstance *LogInstance) ReturnFile() *os.File {
+	if logFile, isFile := logInstance.logOutput.logWriter.(fileProvider); isFile && logFile.currentFile() != nil {
+		return logFile.currentFile()
+	}
+
 	return logInstance.LogDestination
 }
 
+// ReturnWriter returns the writer that receives the file output
+func (logInstance *LogInstance) ReturnWriter() io.Writer {
+	return logInstance.logOutput.logWriter
+}
+
 // printOutPut Print writes the log message to the specified output destinations
+// Fatal messages run the exit behavior and panic messages panic once the log instance is unlocked
 func printOutPut(logInstance *LogInstance, needFileOutput bool,
 	needTerminalOutput bool, needTerminalColoredOutput bool,
 	messageType string, jsonContent map[string]interface{},
 	messageContent ...interface{}) {
-	var messagePrefix string
+	printEntry(logInstance, needFileOutput, needTerminalOutput, needTerminalColoredOutput,
+		messageType, jsonContent, messageContent...)
 
-	// Generate message prefix
+	// Exit if fatal
 
-	getTime := time.Now()
-	generateLongTime := getTime.Format("2006-01-02 15:04:05")
-	generatedTimeMillSeconds := getTime.Nanosecond() / 1e6
-	generatedTimeNanoSeconds := getTime.Nanosecond()
+	if messageType == messageFatal {
+		logInstance.exitProcess()
+	}
 
-	var generatedTime string = generateLongTime + ":" +
-		strconv.Itoa(generatedTimeMillSeconds) + ":" +
-		strconv.Itoa(generatedTimeNanoSeconds)
+	// Panic if panic
 
-	messagePrefix = generatedTime + messageType
+	if messageType == MessagePanic {
+		panic(panicMessage(messageContent))
+	}
+}
 
-	// Print to the file
+// printEntry filters, formats and writes the log message while holding the log instance lock
+func printEntry(logInstance *LogInstance, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool,
+	messageType string, jsonContent map[string]interface{},
+	messageContent ...interface{}) {
+	messageLevel := lookupLevel(messageType)
 
-	if needFileOutput {
-		fmt.Fprint(logInstance.LogDestination, messagePrefix)
-		fmt.Fprint(logInstance.LogDestination, messageContent...)
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
 
-		if jsonContent != nil {
-			logInstance.generateJSON(true, false, jsonContent)
-		}
+	// Drop messages below the selected level or after a shutdown
 
-		fmt.Fprintln(logInstance.LogDestination)
+	if !logInstance.levelEnabled(messageLevel.levelSeverity) || logInstance.logStopped.Load() {
+		return
 	}
 
-	// Print to the terminal
+	entryFields, messageParts := collectFields(logInstance.boundFields, jsonContent, messageContent)
+	evaluateLazy(entryFields, messageParts)
 
-	if needTerminalOutput && needTerminalColoredOutput {
-		var colorCode string
+	messageEntry := logEntry{
+		entryTime:    logInstance.logClock(),
+		messageType:  messageType,
+		messageLevel: messageLevel,
+		entryFields:  entryFields,
+		messageParts: logInstance.detachContent(messageParts),
+	}
 
-		switch messageType {
-		case MessageNormal:
-			colorCode = ColorDefault
+	if logInstance.captureCaller {
+		messageEntry.entryCaller = captureCaller(logInstance.callerSkip)
+	}
 
-		case MessageFatal:
-			colorCode = ColorRed
+	if logInstance.captureStack && messageLevel.levelSeverity >= logInstance.stackLevel {
+		messageEntry.entryStack = captureStack()
+	}
 
-		case MessageWarning:
-			colorCode = ColorYellow
-		}
+	dispatchEntry(logInstance, needFileOutput, needTerminalOutput, needTerminalColoredOutput, messageEntry)
+}
 
-		fmt.Print(colorCode, messagePrefix)
-		fmt.Print(messageContent...)
+// dispatchEntry runs the hooks and writes the log message entry to the specified output destinations
+// Messages below the selected level are kept in the ring buffer instead, sampled or rate limited messages are dropped
+// and consecutive identical messages are collapsed
+// The log instance lock must be held by the caller
+func dispatchEntry(logInstance *LogInstance, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool, messageEntry logEntry) {
+	// Redact the message before the hooks, so remote hooks never send sensitive data,
+	// and again afterwards for the fields the hooks added
 
-		if jsonContent != nil {
-			logInstance.generateJSON(false, true, jsonContent)
-		}
+	redactEntry(logInstance, &messageEntry)
 
-		fmt.Println(ColorDefault)
-	} else if needTerminalOutput {
-		fmt.Print(messagePrefix)
-		fmt.Print(messageContent...)
+	if !runHooks(logInstance, &messageEntry) {
+		return
+	}
 
-		if jsonContent != nil {
-			logInstance.generateJSON(false, true, jsonContent)
-		}
+	messageEntry.messageLevel.levelColor = logInstance.levelColor(messageEntry.messageType, messageEntry.messageLevel.levelColor)
 
-		fmt.Println()
+	if len(logInstance.logHooks) > 0 {
+		redactEntry(logInstance, &messageEntry)
 	}
 
-	// Exit if fatal
+	messageSeverity := messageEntry.messageLevel.levelSeverity
 
-	if messageType == MessageFatal {
-		os.Exit(1)
+	if messageSeverity < logInstance.minimumLevel() {
+		logInstance.logRing.add(messageEntry)
+		return
+	}
+
+	if !logInstance.logSampler.admit(&messageEntry) || !logInstance.rateLimit.admit(&messageEntry) {
+		return
+	}
+
+	if logInstance.logRepeat.suppress(logInstance, needFileOutput, needTerminalOutput,
+		needTerminalColoredOutput, messageEntry) {
+		return
 	}
+
+	messageEntries := []logEntry{messageEntry}
+
+	if logInstance.logRing != nil && messageSeverity >= logInstance.logRing.triggerLevel {
+		messageEntries = append(logInstance.logRing.drain(), messageEntry)
+	}
+
+	writeEntries(logInstance, needFileOutput, needTerminalOutput, needTerminalColoredOutput, messageEntries)
 }
 
-// generateJSON Generate and print JSON content
-func (logInstance *LogInstance) generateJSON(needFileOutPut bool, needTerminalOutput bool,
-	jsonData map[string]interface{}) {
-	if needFileOutPut {
-		fmt.Fprint(logInstance.LogDestination, " [")
+// writeEntries writes the log message entries to the specified output destinations in order
+// The log instance lock must be held by the caller
+func writeEntries(logInstance *LogInstance, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool, messageEntries []logEntry) {
+	// Without a queue the entries are written right away, sparing the task its allocations
+
+	lastType := messageEntries[len(messageEntries)-1].messageType
+
+	if logInstance.logOutput.logQueue == nil && lastType != messageFatal && lastType != MessagePanic {
+		printEntries(logInstance, needFileOutput, needTerminalOutput, needTerminalColoredOutput, messageEntries)
+		return
+	}
+
+	queuedEntries := append([]logEntry(nil), messageEntries...)
+
+	printTask := func() {
+		printEntries(logInstance, needFileOutput, needTerminalOutput, needTerminalColoredOutput, queuedEntries)
 	}
 
-	if needTerminalOutput {
-		fmt.Print(" [")
+	// Wait for every queued message to be written before a fatal exit or a panic
+
+	if lastType == messageFatal || lastType == MessagePanic {
+		logInstance.runTaskWait(func() {
+			printTask()
+			flushOutput(logInstance.logOutput.logWriter)
+			flushDestinations(logInstance)
+		})
+
+		return
 	}
 
-	for jsonKey, jsonValue := range jsonData {
-		if needFileOutPut {
-			fmt.Fprint(logInstance.LogDestination, " (", jsonKey, ": ", jsonValue, ")")
+	logInstance.runTask(printTask, len(messageEntries))
+}
+
+// printEntries formats and writes the log message entries to the specified output destinations in order
+func printEntries(logInstance *LogInstance, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool, messageEntries []logEntry) {
+	fileFormatter := logInstance.selectFormatter(logInstance.fileFormatter)
+	terminalFormatter := logInstance.selectFormatter(logInstance.terminalFormatter)
+
+	for _, printedEntry := range messageEntries {
+		if logInstance.fileFormatter == nil && logInstance.terminalFormatter == nil &&
+			logInstance.fileFilter == nil && logInstance.terminalFilter == nil {
+			printFormatted(logInstance, fileFormatter, needFileOutput, needTerminalOutput,
+				needTerminalColoredOutput, printedEntry)
+		} else {
+			printFormatted(logInstance, fileFormatter, needFileOutput, false, false,
+				logInstance.fileFilter.apply(printedEntry))
+			printFormatted(logInstance, terminalFormatter, false, needTerminalOutput,
+				needTerminalColoredOutput, logInstance.terminalFilter.apply(printedEntry))
 		}
 
-		if needTerminalOutput {
-			fmt.Print(" (", jsonKey, ": ", jsonValue, ")")
+		writeDestinations(logInstance, printedEntry)
+	}
+}
+
+// printText writes the log message in the text format to the specified output destinations
+// The line is built once in a pooled buffer and written with a single call per output
+func printText(logInstance *LogInstance, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool, messageEntry logEntry) {
+	lineBuffer := acquireBuffer()
+	defer releaseBuffer(lineBuffer)
+
+	*lineBuffer = appendTimestamp(logInstance, *lineBuffer, messageEntry.entryTime)
+	timeLength := len(*lineBuffer)
+
+	*lineBuffer = append(appendTextLine(logInstance, *lineBuffer, messageEntry), '\n')
+	textLine := *lineBuffer
+
+	// Print to the file
+
+	if needFileOutput {
+		rotateOutput(logInstance)
+
+		if _, writeError := logInstance.logOutput.logWriter.Write(textLine); writeError != nil {
+			reportWriteError(logInstance, writeError, messageEntry, string(textLine[:len(textLine)-1]))
 		}
 	}
 
-	if needFileOutPut {
-		fmt.Fprint(logInstance.LogDestination, " ]")
+	// Print to the terminal
+
+	terminalWriter := logInstance.terminalWriter(messageEntry.messageLevel.levelSeverity)
+
+	colorsEnabled := needTerminalOutput && logInstance.colorEnabled(needTerminalColoredOutput, terminalWriter)
+
+	if colorsEnabled && logInstance.logTheme != nil {
+		io.WriteString(terminalWriter, logInstance.logTheme.themedText(string(textLine[:timeLength]),
+			logInstance.levelLabel(messageEntry.messageType), messageEntry))
+	} else if colorsEnabled {
+		coloredBuffer := acquireBuffer()
+		defer releaseBuffer(coloredBuffer)
+
+		*coloredBuffer = append(*coloredBuffer, degradeColor(messageEntry.messageLevel.levelColor)...)
+		*coloredBuffer = append(*coloredBuffer, textLine[:len(textLine)-1]...)
+		*coloredBuffer = append(append(*coloredBuffer, ColorDefault...), '\n')
+
+		terminalWriter.Write(*coloredBuffer)
+	} else if needTerminalOutput {
+		terminalWriter.Write(textLine)
 	}
+}
 
-	if needTerminalOutput {
-		fmt.Print(" ]")
+// appendTextLine appends the text form of the log message following its time, without the final line break, to the line buffer
+func appendTextLine(logInstance *LogInstance, lineBuffer []byte, messageEntry logEntry) []byte {
+	lineBuffer = append(lineBuffer, logInstance.levelLabel(messageEntry.messageType)...)
+	lineBuffer = appendEntryText(lineBuffer, messageEntry)
+
+	if messageEntry.entryStack != "" {
+		lineBuffer = append(lineBuffer, '\n')
+		lineBuffer = append(lineBuffer, messageEntry.entryStack...)
 	}
+
+	return lineBuffer
 }
@@ -0,0 +1,188 @@
+// JSON Line Output Format
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	jsonKeyTime     string = "time"     // jsonKeyTime is the key holding the message time
+	jsonKeyLevel    string = "level"    // jsonKeyLevel is the key holding the level name
+	jsonKeyMessage  string = "message"  // jsonKeyMessage is the key holding the message content
+	jsonKeyCaller   string = "caller"   // jsonKeyCaller is the key holding the source file and line
+	jsonKeyFunction string = "function" // jsonKeyFunction is the key holding the calling function
+	jsonKeyStack    string = "stack"    // jsonKeyStack is the key holding the stack trace
+	jsonKeyFields   string = "fields."  // jsonKeyFields prefixes fields colliding with the reserved keys
+)
+
+// printLine writes the log message encoded as a single line to the specified output destinations
+// It is used by formatters and every format except the text format
+func printLine(logInstance *LogInstance, logFormatter Formatter, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool, messageEntry logEntry) {
+	messageLevel := messageEntry.messageLevel
+	encodedLine := encodeEntry(logInstance, logFormatter, messageEntry)
+
+	lineBuffer := acquireBuffer()
+	defer releaseBuffer(lineBuffer)
+
+	*lineBuffer = append(append(*lineBuffer, encodedLine...), '\n')
+
+	// Print to the file
+
+	if needFileOutput {
+		rotateOutput(logInstance)
+
+		if _, writeError := logInstance.logOutput.logWriter.Write(*lineBuffer); writeError != nil {
+			reportWriteError(logInstance, writeError, messageEntry, encodedLine)
+		}
+	}
+
+	// Print to the terminal
+
+	terminalWriter := logInstance.terminalWriter(messageLevel.levelSeverity)
+
+	if needTerminalOutput && logInstance.colorEnabled(needTerminalColoredOutput, terminalWriter) {
+		coloredBuffer := acquireBuffer()
+		defer releaseBuffer(coloredBuffer)
+
+		*coloredBuffer = append(*coloredBuffer, degradeColor(messageLevel.levelColor)...)
+		*coloredBuffer = append(append(*coloredBuffer, encodedLine...), ColorDefault...)
+		terminalWriter.Write(append(*coloredBuffer, '\n'))
+	} else if needTerminalOutput {
+		terminalWriter.Write(*lineBuffer)
+	}
+}
+
+// jsonTime returns the time of a log message as a field for the JSON format
+// Unix timestamps are integers and the default time format is replaced with RFC 3339
+func jsonTime(logInstance *LogInstance, getTime time.Time) Field {
+	if unixTime, isUnix := unixTimestamp(logInstance, getTime); isUnix {
+		return Int64(jsonKeyTime, unixTime)
+	}
+
+	if logInstance.timeFormat == TimeFormatDefault && logInstance.timePrecision == PrecisionLegacy {
+		return String(jsonKeyTime, localTime(logInstance, getTime).Format(TimeFormatRFC3339Nano))
+	}
+
+	return String(jsonKeyTime, generateTimestamp(logInstance, getTime))
+}
+
+// encodeJSON encodes the message time, level, content and fields as a JSON object
+// The reserved keys are written first, followed by the fields in their collected order
+func encodeJSON(timeField Field, messageEntry logEntry) string {
+	jsonBuffer := []byte{'{'}
+
+	jsonBuffer = appendJSONField(jsonBuffer, jsonKeyTime, timeField)
+	jsonBuffer = append(jsonBuffer, ',')
+	jsonBuffer = appendJSONPair(jsonBuffer, jsonKeyLevel, messageEntry.messageLevel.levelName)
+
+	if messageEntry.entryCaller != nil {
+		jsonBuffer = append(jsonBuffer, ',')
+		jsonBuffer = appendJSONPair(jsonBuffer, jsonKeyCaller, messageEntry.entryCaller.shortFile())
+		jsonBuffer = append(jsonBuffer, ',')
+		jsonBuffer = appendJSONPair(jsonBuffer, jsonKeyFunction, messageEntry.entryCaller.callerFunction)
+	}
+
+	jsonBuffer = append(jsonBuffer, ',')
+	jsonBuffer = appendJSONPair(jsonBuffer, jsonKeyMessage, fmt.Sprint(messageEntry.messageParts...))
+
+	for _, entryField := range messageEntry.entryFields {
+		fieldKey := entryField.Key
+
+		switch fieldKey {
+		case jsonKeyTime, jsonKeyLevel, jsonKeyMessage, jsonKeyCaller, jsonKeyFunction, jsonKeyStack:
+			fieldKey = jsonKeyFields + fieldKey
+		}
+
+		jsonBuffer = append(jsonBuffer, ',')
+		jsonBuffer = appendJSONField(jsonBuffer, fieldKey, entryField)
+	}
+
+	if messageEntry.entryStack != "" {
+		jsonBuffer = append(jsonBuffer, ',')
+		jsonBuffer = appendJSONPair(jsonBuffer, jsonKeyStack, messageEntry.entryStack)
+	}
+
+	return string(append(jsonBuffer, '}'))
+}
+
+// appendJSONPair appends a JSON encoded key and value to the buffer
+// Nested values are normalized first and values that cannot be encoded fall back to their text form
+// String values are encoded without reflection
+func appendJSONPair(jsonBuffer []byte, jsonKey string, jsonValue interface{}) []byte {
+	jsonBuffer = append(appendJSONString(jsonBuffer, jsonKey), ':')
+
+	if stringValue, isString := jsonValue.(string); isString {
+		return appendJSONString(jsonBuffer, stringValue)
+	}
+
+	encodedValue, encodeError := json.Marshal(normalizeValue(jsonValue, 0))
+
+	if encodeError != nil {
+		encodedValue, _ = json.Marshal(fmt.Sprint(jsonValue))
+	}
+
+	return append(jsonBuffer, encodedValue...)
+}
+
+// appendJSONField appends a JSON encoded key and typed field value to the buffer
+// String, integer, boolean, finite float, duration and error values are encoded without reflection or allocation
+func appendJSONField(jsonBuffer []byte, jsonKey string, logField Field) []byte {
+	switch logField.valueType {
+	case fieldString:
+		jsonBuffer = append(appendJSONString(jsonBuffer, jsonKey), ':')
+
+		return appendJSONString(jsonBuffer, logField.stringValue)
+
+	case fieldInteger:
+		jsonBuffer = append(appendJSONString(jsonBuffer, jsonKey), ':')
+
+		return strconv.AppendInt(jsonBuffer, logField.integerValue, 10)
+
+	case fieldBoolean:
+		jsonBuffer = append(appendJSONString(jsonBuffer, jsonKey), ':')
+
+		return strconv.AppendBool(jsonBuffer, logField.integerValue == 1)
+
+	case fieldFloat:
+		floatValue := math.Float64frombits(uint64(logField.integerValue))
+
+		if math.IsNaN(floatValue) || math.IsInf(floatValue, 0) {
+			break
+		}
+
+		jsonBuffer = append(appendJSONString(jsonBuffer, jsonKey), ':')
+
+		return appendJSONFloat(jsonBuffer, floatValue)
+
+	case fieldDuration:
+		jsonBuffer = append(appendJSONString(jsonBuffer, jsonKey), ':', '"')
+
+		return append(appendDuration(jsonBuffer, time.Duration(logField.integerValue)), '"')
+
+	case fieldError:
+		if logField.interfaceValue == nil {
+			break
+		}
+
+		jsonBuffer = append(appendJSONString(jsonBuffer, jsonKey), ':')
+
+		return appendJSONString(jsonBuffer, logField.interfaceValue.(error).Error())
+
+	case fieldAny:
+		return appendJSONPair(jsonBuffer, jsonKey, logField.interfaceValue)
+	}
+
+	return appendJSONPair(jsonBuffer, jsonKey, logField.Value())
+}
@@ -0,0 +1,115 @@
+// Systemd Journal Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build linux
+
+package GoLog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// journalSocket is the path of the native protocol socket of systemd-journald
+const journalSocket string = "/run/systemd/journal/socket"
+
+// JournaldDestination writes log messages to systemd-journald using its native protocol
+// Every field of a message becomes a journal field with an upper case name
+type JournaldDestination struct {
+	journalConnection *net.UnixConn // journalConnection is the datagram connection to the journal socket
+	journalIdentifier string        // journalIdentifier is written as the SYSLOG_IDENTIFIER field
+}
+
+// NewJournaldDestination connects to the journal socket of the local systemd-journald
+// Messages are tagged with journalIdentifier so they can be selected with journalctl -t
+func NewJournaldDestination(journalIdentifier string) (*JournaldDestination, error) {
+	journalConnection, dialError := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+
+	if dialError != nil {
+		return nil, dialError
+	}
+
+	return &JournaldDestination{journalConnection: journalConnection, journalIdentifier: journalIdentifier}, nil
+}
+
+// WriteEntry sends the log message as a single datagram of journal fields
+func (journaldDestination *JournaldDestination) WriteEntry(logEntry Entry) error {
+	return journaldDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry sends the log message as a single datagram of journal fields
+func (journaldDestination *JournaldDestination) writeEntry(_ *LogInstance, messageEntry logEntry) error {
+	var journalMessage []byte
+
+	journalMessage = appendJournalField(journalMessage, "MESSAGE", fmt.Sprint(messageEntry.messageParts...))
+	journalMessage = appendJournalField(journalMessage, "PRIORITY",
+		strconv.Itoa(syslogSeverity(messageEntry.messageLevel.levelSeverity)))
+
+	if journaldDestination.journalIdentifier != "" {
+		journalMessage = appendJournalField(journalMessage, "SYSLOG_IDENTIFIER", journaldDestination.journalIdentifier)
+	}
+
+	if messageEntry.entryCaller != nil {
+		journalMessage = appendJournalField(journalMessage, "CODE_FILE", messageEntry.entryCaller.callerFile)
+		journalMessage = appendJournalField(journalMessage, "CODE_LINE", strconv.Itoa(messageEntry.entryCaller.callerLine))
+		journalMessage = appendJournalField(journalMessage, "CODE_FUNC", messageEntry.entryCaller.callerFunction)
+	}
+
+	for _, entryField := range messageEntry.entryFields {
+		journalMessage = appendJournalField(journalMessage, journalFieldName(entryField.Key), fieldText(entryField))
+	}
+
+	if messageEntry.entryStack != "" {
+		journalMessage = appendJournalField(journalMessage, "STACK_TRACE", messageEntry.entryStack)
+	}
+
+	_, writeError := journaldDestination.journalConnection.Write(journalMessage)
+
+	return writeError
+}
+
+// Close closes the connection to the journal socket
+func (journaldDestination *JournaldDestination) Close() error {
+	return journaldDestination.journalConnection.Close()
+}
+
+// appendJournalField appends a journal field in the native protocol format
+// Values containing a newline are written with their length as a little endian prefix
+func appendJournalField(journalMessage []byte, fieldName string, fieldValue string) []byte {
+	if !strings.Contains(fieldValue, "\n") {
+		return append(append(append(journalMessage, fieldName...), '='), fieldValue+"\n"...)
+	}
+
+	journalMessage = append(append(journalMessage, fieldName...), '\n')
+	journalMessage = binary.LittleEndian.AppendUint64(journalMessage, uint64(len(fieldValue)))
+
+	return append(journalMessage, fieldValue+"\n"...)
+}
+
+// journalFieldName converts a field key into a valid journal field name
+// Letters are upper cased, other characters are replaced with underscores and leading underscores are removed
+func journalFieldName(fieldKey string) string {
+	fieldName := []byte(strings.ToUpper(fieldKey))
+
+	for characterIndex, fieldCharacter := range fieldName {
+		if (fieldCharacter < 'A' || fieldCharacter > 'Z') && (fieldCharacter < '0' || fieldCharacter > '9') {
+			fieldName[characterIndex] = '_'
+		}
+	}
+
+	journalName := strings.TrimLeft(string(fieldName), "_")
+
+	if journalName == "" || (journalName[0] >= '0' && journalName[0] <= '9') {
+		journalName = "FIELD_" + journalName
+	}
+
+	return journalName
+}
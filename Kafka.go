@@ -0,0 +1,57 @@
+// Kafka Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// KafkaProducer publishes a message to a Kafka topic
+// It is implemented by a thin wrapper around the producer of the Kafka client used by the application
+// Messages with the same key are expected to land on the same partition
+type KafkaProducer interface {
+	Produce(kafkaTopic string, messageKey []byte, messageValue []byte) error // Produce publishes a single message
+}
+
+// KafkaDestination publishes every log message as a JSON object to a Kafka topic
+type KafkaDestination struct {
+	kafkaProducer KafkaProducer // kafkaProducer publishes the messages
+	kafkaTopic    string        // kafkaTopic is the topic receiving the messages
+	keyField      string        // keyField is the key of the field used as message key
+}
+
+// NewKafkaDestination returns a destination publishing log messages to kafkaTopic through kafkaProducer
+// The value of the field named keyField is used as message key to select the partition
+// Messages without that field, or every message if keyField is empty, are published without a key
+func NewKafkaDestination(kafkaProducer KafkaProducer, kafkaTopic string, keyField string) *KafkaDestination {
+	return &KafkaDestination{kafkaProducer: kafkaProducer, kafkaTopic: kafkaTopic, keyField: keyField}
+}
+
+// WriteEntry encodes the log message and publishes it
+func (kafkaDestination *KafkaDestination) WriteEntry(logEntry Entry) error {
+	return kafkaDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message and publishes it
+func (kafkaDestination *KafkaDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	var messageKey []byte
+
+	if kafkaDestination.keyField != "" {
+		for _, entryField := range messageEntry.entryFields {
+			if entryField.Key == kafkaDestination.keyField {
+				messageKey = []byte(fieldText(entryField))
+			}
+		}
+	}
+
+	messageValue := encodeJSON(jsonTime(logInstance, messageEntry.entryTime), messageEntry)
+
+	return kafkaDestination.kafkaProducer.Produce(kafkaDestination.kafkaTopic, messageKey, []byte(messageValue))
+}
+
+// Close releases the destination, the producer itself is closed by the application
+func (kafkaDestination *KafkaDestination) Close() error {
+	return nil
+}
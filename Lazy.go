@@ -0,0 +1,42 @@
+// Lazy Message and Field Evaluation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// Lazy constructs a field whose value is computed by valueFunction only when the message is written
+// Any field or structured content value of type func() interface{} is evaluated in the same way,
+// and message content of type func() string is replaced by the text it returns
+// Expensive values in debug messages then cost nothing when the debug level is disabled
+func Lazy(fieldKey string, valueFunction func() interface{}) Field {
+	return Any(fieldKey, valueFunction)
+}
+
+// evaluateLazy replaces the lazy field values and message content of a message that passed the level filter
+// with what their functions return, in place, so the slices must belong to the message
+func evaluateLazy(entryFields []Field, messageParts []interface{}) {
+	for fieldIndex, entryField := range entryFields {
+		if valueFunction, isLazy := entryField.interfaceValue.(func() interface{}); isLazy && entryField.valueType == fieldAny {
+			entryFields[fieldIndex] = Any(entryField.Key, callLazy(valueFunction))
+		}
+	}
+
+	for partIndex, messagePart := range messageParts {
+		if messageFunction, isLazy := messagePart.(func() string); isLazy && messageFunction != nil {
+			messageParts[partIndex] = messageFunction()
+		}
+	}
+}
+
+// callLazy returns the value of the lazy function, nil when there is no function
+func callLazy(valueFunction func() interface{}) interface{} {
+	if valueFunction == nil {
+		return nil
+	}
+
+	return valueFunction()
+}
@@ -0,0 +1,214 @@
+// Log Level Filtering
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Level represents the severity of a log message
+type Level int
+
+const (
+	LevelTrace   Level = 10 // LevelTrace represents the severity of trace messages
+	LevelDebug   Level = 20 // LevelDebug represents the severity of debug messages
+	LevelNormal  Level = 30 // LevelNormal represents the severity of normal messages
+	LevelWarning Level = 40 // LevelWarning represents the severity of warning messages
+	LevelError   Level = 45 // LevelError represents the severity of error messages
+	LevelPanic   Level = 48 // LevelPanic represents the severity of panic messages
+	LevelFatal   Level = 50 // LevelFatal represents the severity of fatal error messages
+)
+
+// levelDefinition holds the properties of a message identifier
+type levelDefinition struct {
+	levelName     string // levelName is the human readable name of the level
+	levelColor    string // levelColor is the ANSI escape sequence used for colored output
+	levelSeverity Level  // levelSeverity is the severity used for filtering
+}
+
+var (
+	levelRegistry = map[string]levelDefinition{
+		MessageTrace:   {levelName: "trace", levelColor: ColorBlue, levelSeverity: LevelTrace},
+		MessageDebug:   {levelName: "debug", levelColor: ColorCyan, levelSeverity: LevelDebug},
+		MessageNormal:  {levelName: "info", levelColor: ColorDefault, levelSeverity: LevelNormal},
+		MessageWarning: {levelName: "warning", levelColor: ColorYellow, levelSeverity: LevelWarning},
+		MessageError:   {levelName: "error", levelColor: ColorRed, levelSeverity: LevelError},
+		MessagePanic:   {levelName: "panic", levelColor: ColorRed, levelSeverity: LevelPanic},
+		messageFatal:   {levelName: "fatal", levelColor: ColorRed, levelSeverity: LevelFatal},
+	} // levelRegistry maps every message identifier to its level definition
+
+	levelRegistryLock sync.RWMutex // levelRegistryLock guards the level registry
+)
+
+// RegisterLevel registers a user defined level and returns its message identifier
+// The returned identifier can be passed to the Custom logging methods
+// If the name or the label is already in use, it returns an error
+func RegisterLevel(levelName string, levelLabel string, levelColor string, levelSeverity Level) (string, error) {
+	messageType := " [ " + levelLabel + " ] "
+
+	levelRegistryLock.Lock()
+	defer levelRegistryLock.Unlock()
+
+	if _, labelExists := levelRegistry[messageType]; labelExists {
+		return "", fmt.Errorf("level label %q is already registered", levelLabel)
+	}
+
+	for _, registeredLevel := range levelRegistry {
+		if registeredLevel.levelName == levelName {
+			return "", fmt.Errorf("level name %q is already registered", levelName)
+		}
+	}
+
+	levelRegistry[messageType] = levelDefinition{
+		levelName:     levelName,
+		levelColor:    levelColor,
+		levelSeverity: levelSeverity,
+	}
+
+	return messageType, nil
+}
+
+// SetLevel sets the minimum severity a message needs to be written
+// Messages below the selected level are dropped before formatting
+// The level is shared with the parent and every child logger created with With, Named, WithRateLimit or NewLogr,
+// so the change reaches all of them, SetLevelFor sets the level of named loggers alone
+func (logInstance *LogInstance) SetLevel(logLevel Level) {
+	logInstance.logLevel.Store(int64(logLevel))
+}
+
+// newSharedLevel returns the level holder shared by a log instance and every child logger copied from it
+func newSharedLevel(logLevel Level) *atomic.Int64 {
+	sharedLevel := &atomic.Int64{}
+	sharedLevel.Store(int64(logLevel))
+
+	return sharedLevel
+}
+
+// GetLevel returns the minimum severity a message needs to be written
+// For a named logger, the level of a matching SetLevelFor override is returned
+func (logInstance *LogInstance) GetLevel() Level {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	return logInstance.minimumLevel()
+}
+
+// IsLevelEnabled reports whether a message of the severity would be written or kept in the ring buffer
+// It guards the preparation of expensive message content along with lazy values
+func (logInstance *LogInstance) IsLevelEnabled(logLevel Level) bool {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	return logInstance.levelEnabled(logLevel)
+}
+
+// DebugEnabled reports whether debug messages would be written or kept in the ring buffer
+func (logInstance *LogInstance) DebugEnabled() bool {
+	return logInstance.IsLevelEnabled(LevelDebug)
+}
+
+// ParseLevel returns the severity of the level name, such as debug or warning, or of a numeric severity
+// The names of user defined levels are accepted as well, warn is accepted for warning
+func ParseLevel(levelName string) (Level, error) {
+	levelName = strings.ToLower(strings.TrimSpace(levelName))
+
+	if levelName == "warn" {
+		return LevelWarning, nil
+	}
+
+	if levelSeverity, parseError := strconv.Atoi(levelName); parseError == nil {
+		return Level(levelSeverity), nil
+	}
+
+	levelRegistryLock.RLock()
+	defer levelRegistryLock.RUnlock()
+
+	for _, registeredLevel := range levelRegistry {
+		if registeredLevel.levelName == levelName {
+			return registeredLevel.levelSeverity, nil
+		}
+	}
+
+	return 0, fmt.Errorf("level name %q is not registered", levelName)
+}
+
+// String returns the name of the level registered with the severity, or the severity as a number
+func (logLevel Level) String() string {
+	levelRegistryLock.RLock()
+	defer levelRegistryLock.RUnlock()
+
+	for _, registeredLevel := range levelRegistry {
+		if registeredLevel.levelSeverity == logLevel {
+			return registeredLevel.levelName
+		}
+	}
+
+	return strconv.Itoa(int(logLevel))
+}
+
+// lookupLevel returns the level definition of the selected message identifier
+// Unknown message identifiers are treated as normal messages
+func lookupLevel(messageType string) levelDefinition {
+	levelRegistryLock.RLock()
+	defer levelRegistryLock.RUnlock()
+
+	if registeredLevel, levelExists := levelRegistry[messageType]; levelExists {
+		return registeredLevel
+	}
+
+	return levelRegistry[MessageNormal]
+}
+
+// lookupSeverity returns the message identifier and the level definition registered with the severity
+// The built-in levels take precedence over user defined levels sharing their severity, such as audit,
+// and severities without a registered level are treated as normal messages
+func lookupSeverity(logLevel Level) (string, levelDefinition) {
+	levelRegistryLock.RLock()
+	defer levelRegistryLock.RUnlock()
+
+	for _, messageType := range []string{MessageTrace, MessageDebug, MessageNormal, MessageWarning,
+		MessageError, MessagePanic, messageFatal} {
+		if levelRegistry[messageType].levelSeverity == logLevel {
+			return messageType, levelRegistry[messageType]
+		}
+	}
+
+	for messageType, registeredLevel := range levelRegistry {
+		if registeredLevel.levelSeverity == logLevel {
+			return messageType, registeredLevel
+		}
+	}
+
+	return MessageNormal, levelRegistry[MessageNormal]
+}
+
+// syslogSeverity returns the syslog severity matching the level severity
+// Panic and fatal messages are critical, lower levels map to error, warning, informational and debug
+func syslogSeverity(levelSeverity Level) int {
+	switch {
+	case levelSeverity >= LevelPanic:
+		return 2
+
+	case levelSeverity >= LevelError:
+		return 3
+
+	case levelSeverity >= LevelWarning:
+		return 4
+
+	case levelSeverity >= LevelNormal:
+		return 6
+
+	default:
+		return 7
+	}
+}
@@ -0,0 +1,89 @@
+// Runtime Level Endpoint
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// levelPayload is the JSON document read and written by the level endpoint
+type levelPayload struct {
+	Level string `json:"level,omitempty"` // Level is the name of the level
+	Error string `json:"error,omitempty"` // Error describes why a change was refused
+}
+
+// LevelHandler returns an HTTP handler reading and changing the level of the log instance at runtime
+// GET answers {"level":"info"}, PUT takes the new level as {"level":"debug"}, a level form value or a level query parameter
+// The handler only changes this log instance, so mount it on an administrative port rather than a public one
+func (logInstance *LogInstance) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		switch httpRequest.Method {
+		case http.MethodGet:
+			writeLevelPayload(responseWriter, http.StatusOK, levelPayload{Level: logInstance.GetLevel().String()})
+
+		case http.MethodPut:
+			levelName, readError := requestedLevel(httpRequest)
+
+			if readError != nil {
+				writeLevelPayload(responseWriter, http.StatusBadRequest, levelPayload{Error: readError.Error()})
+				return
+			}
+
+			logLevel, parseError := ParseLevel(levelName)
+
+			if parseError != nil {
+				writeLevelPayload(responseWriter, http.StatusBadRequest, levelPayload{Error: parseError.Error()})
+				return
+			}
+
+			logInstance.SetLevel(logLevel)
+			writeLevelPayload(responseWriter, http.StatusOK, levelPayload{Level: logLevel.String()})
+
+		default:
+			responseWriter.Header().Set("Allow", "GET, PUT")
+			writeLevelPayload(responseWriter, http.StatusMethodNotAllowed,
+				levelPayload{Error: "only GET and PUT are supported"})
+		}
+	})
+}
+
+// requestedLevel returns the level name of the PUT request from its JSON body, its form or its query
+func requestedLevel(httpRequest *http.Request) (string, error) {
+	if !strings.HasPrefix(httpRequest.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		var requestPayload levelPayload
+
+		decodeError := json.NewDecoder(io.LimitReader(httpRequest.Body, 4096)).Decode(&requestPayload)
+
+		if decodeError != nil && decodeError != io.EOF {
+			return "", decodeError
+		}
+
+		if requestPayload.Level != "" {
+			return requestPayload.Level, nil
+		}
+	}
+
+	if levelName := httpRequest.FormValue("level"); levelName != "" {
+		return levelName, nil
+	}
+
+	return "", errors.New("the request holds no level")
+}
+
+// writeLevelPayload answers the request with the status and the JSON document
+func writeLevelPayload(responseWriter http.ResponseWriter, statusCode int, responsePayload levelPayload) {
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(statusCode)
+
+	json.NewEncoder(responseWriter).Encode(responsePayload)
+}
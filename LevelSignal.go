@@ -0,0 +1,74 @@
+// Level Changes on Signals
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"os"
+	"os/signal"
+)
+
+// levelSteps are the severities a level signal moves between, from the most to the least verbose
+var levelSteps = []Level{LevelTrace, LevelDebug, LevelNormal, LevelWarning, LevelError, LevelPanic, LevelFatal}
+
+// LevelOnSignal changes the level every time the process receives a level signal
+// SIGUSR1 makes the log instance one level more verbose and SIGUSR2 one level less verbose,
+// so a long-running daemon can be diagnosed with kill -USR1 and quietened again with kill -USR2
+// The level is shared with every child logger, so loggers created with With or Named follow the change
+// It returns a function that stops handling the signals, outside Unix systems the signals do not exist and nothing is handled
+func (logInstance *LogInstance) LevelOnSignal() func() {
+	if raiseSignal == nil {
+		return func() {}
+	}
+
+	signalChannel := make(chan os.Signal, 1)
+	stopChannel := make(chan struct{})
+
+	signal.Notify(signalChannel, raiseSignal, lowerSignal)
+
+	go func() {
+		for {
+			select {
+			case receivedSignal := <-signalChannel:
+				logInstance.logLock.Lock()
+				logInstance.SetLevel(stepLevel(Level(logInstance.logLevel.Load()), receivedSignal == raiseSignal))
+				logInstance.logLock.Unlock()
+
+			case <-stopChannel:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signalChannel)
+		close(stopChannel)
+	}
+}
+
+// stepLevel returns the next more verbose level when needVerbose is set, the next less verbose level otherwise
+// Levels between the built-in ones step to the nearest built-in level in that direction
+func stepLevel(currentLevel Level, needVerbose bool) Level {
+	if needVerbose {
+		for stepIndex := len(levelSteps) - 1; stepIndex >= 0; stepIndex-- {
+			if levelSteps[stepIndex] < currentLevel {
+				return levelSteps[stepIndex]
+			}
+		}
+
+		return currentLevel
+	}
+
+	for _, stepSeverity := range levelSteps {
+		if stepSeverity > currentLevel {
+			return stepSeverity
+		}
+	}
+
+	return currentLevel
+}
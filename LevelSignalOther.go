@@ -0,0 +1,19 @@
+// Level Signals on Other Systems
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build !unix
+
+package GoLog
+
+import "os"
+
+// raiseSignal and lowerSignal are nil because user defined signals exist on Unix systems only
+var (
+	raiseSignal os.Signal
+	lowerSignal os.Signal
+)
@@ -0,0 +1,22 @@
+// Level Signals on Unix Systems
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build unix
+
+package GoLog
+
+import (
+	"os"
+	"syscall"
+)
+
+// raiseSignal and lowerSignal make the log instance more and less verbose
+var (
+	raiseSignal os.Signal = syscall.SIGUSR1
+	lowerSignal os.Signal = syscall.SIGUSR2
+)
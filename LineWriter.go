@@ -0,0 +1,159 @@
+// Line Transforming Writer
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// lineWriter hands the complete lines of the file output to a transformation before they reach the wrapped writer
+// The output after the last complete line is kept until its line is complete
+type lineWriter struct {
+	lineLock       sync.Mutex                                          // lineLock guards the pending output
+	targetWriter   io.Writer                                           // targetWriter receives the transformed lines
+	writeLines     func(targetWriter io.Writer, logLines []byte) error // writeLines transforms the lines and writes them to the wrapped writer
+	writeFailure   error                                               // writeFailure is returned by every write when set
+	holdIncomplete bool                                                // holdIncomplete keeps the incomplete line on flush until the writer is closed
+	pendingOutput  []byte                                              // pendingOutput holds the output after the last complete line
+}
+
+// Write transforms the complete lines of the content and keeps the rest until its line is complete
+func (logLines *lineWriter) Write(writeContent []byte) (int, error) {
+	if logLines.writeFailure != nil {
+		return 0, logLines.writeFailure
+	}
+
+	logLines.lineLock.Lock()
+	defer logLines.lineLock.Unlock()
+
+	logLines.pendingOutput = append(logLines.pendingOutput, writeContent...)
+	lineEnd := bytes.LastIndexByte(logLines.pendingOutput, '\n')
+
+	if lineEnd < 0 {
+		return len(writeContent), nil
+	}
+
+	writeError := logLines.writeLines(logLines.targetWriter, logLines.pendingOutput[:lineEnd+1])
+	logLines.pendingOutput = append(logLines.pendingOutput[:0], logLines.pendingOutput[lineEnd+1:]...)
+
+	return len(writeContent), writeError
+}
+
+// Flush transforms the incomplete line and flushes the wrapped writer when it buffers its output
+func (logLines *lineWriter) Flush() error {
+	return logLines.flush(!logLines.holdIncomplete)
+}
+
+// flush transforms the incomplete line when needIncomplete is set and flushes the wrapped writer
+func (logLines *lineWriter) flush(needIncomplete bool) error {
+	logLines.lineLock.Lock()
+
+	if needIncomplete && len(logLines.pendingOutput) > 0 && logLines.writeFailure == nil {
+		writeError := logLines.writeLines(logLines.targetWriter, logLines.pendingOutput)
+		logLines.pendingOutput = logLines.pendingOutput[:0]
+
+		if writeError != nil {
+			logLines.lineLock.Unlock()
+			return writeError
+		}
+	}
+
+	logLines.lineLock.Unlock()
+
+	if bufferedWriter, isBuffered := logLines.targetWriter.(flushWriter); isBuffered {
+		return bufferedWriter.Flush()
+	}
+
+	return nil
+}
+
+// Sync flushes the writer and commits the wrapped writer to stable storage
+func (logLines *lineWriter) Sync() error {
+	if flushError := logLines.Flush(); flushError != nil {
+		return flushError
+	}
+
+	if storageWriter, isStorage := logLines.targetWriter.(syncWriter); isStorage && !isStandardStream(logLines.targetWriter) {
+		return storageWriter.Sync()
+	}
+
+	return nil
+}
+
+// Close transforms the incomplete line and closes the wrapped writer
+func (logLines *lineWriter) Close() error {
+	flushError := logLines.flush(true)
+
+	if logCloser, isCloser := logLines.targetWriter.(io.Closer); isCloser && !isStandardStream(logLines.targetWriter) {
+		if closeError := logCloser.Close(); flushError == nil {
+			flushError = closeError
+		}
+	}
+
+	return flushError
+}
+
+// needsRotation reports whether the wrapped writer meets a rotation condition
+func (logLines *lineWriter) needsRotation(pendingSize int64) bool {
+	logRotate, isRotator := logLines.targetWriter.(entryRotator)
+
+	if !isRotator {
+		return false
+	}
+
+	logLines.lineLock.Lock()
+	defer logLines.lineLock.Unlock()
+
+	return logRotate.needsRotation(pendingSize + int64(len(logLines.pendingOutput)))
+}
+
+// rotateIfNeeded flushes the writer and lets the wrapped writer rotate when it meets a rotation condition
+func (logLines *lineWriter) rotateIfNeeded() error {
+	if !logLines.needsRotation(0) {
+		return nil
+	}
+
+	if flushError := logLines.Flush(); flushError != nil {
+		return flushError
+	}
+
+	return logLines.targetWriter.(entryRotator).rotateIfNeeded()
+}
+
+// currentFile returns the log file of the wrapped writer
+func (logLines *lineWriter) currentFile() *os.File {
+	if logFile, isFile := logLines.targetWriter.(fileProvider); isFile {
+		return logFile.currentFile()
+	}
+
+	if fileDescriptor, isFile := logLines.targetWriter.(*os.File); isFile {
+		return fileDescriptor
+	}
+
+	return nil
+}
+
+// reopen flushes the writer and lets the wrapped writer reopen its log file
+func (logLines *lineWriter) reopen() error {
+	logReopen, isReopener := logLines.targetWriter.(fileReopener)
+
+	if !isReopener {
+		return errors.New("log instance has no file path to reopen")
+	}
+
+	if flushError := logLines.Flush(); flushError != nil {
+		return flushError
+	}
+
+	return logReopen.reopen()
+}
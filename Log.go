@@ -17,3 +17,8 @@ func (logInstance *LogInstance) Log(jsonContent map[string]interface{}, messageC
 func (logInstance *LogInstance) FLog(jsonContent map[string]interface{}, messageContent ...interface{}) {
 	printOutPut(logInstance, true, false, false, MessageNormal, jsonContent, messageContent...)
 }
+
+// Info logs a message to the configured destinations with normal formatting
+func (logInstance *LogInstance) Info(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageNormal, jsonContent, messageContent...)
+}
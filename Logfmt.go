@@ -0,0 +1,80 @@
+// Logfmt Line Output Format
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	logfmtKeyTime    string = "ts"  // logfmtKeyTime is the key holding the message time
+	logfmtKeyMessage string = "msg" // logfmtKeyMessage is the key holding the message content
+)
+
+// encodeLogfmt encodes the message time, level, content and fields as a logfmt line
+// The reserved keys are written first, followed by the fields in their collected order
+func encodeLogfmt(timeField Field, messageEntry logEntry) string {
+	var logfmtBuilder strings.Builder
+
+	appendLogfmtPair(&logfmtBuilder, logfmtKeyTime, fieldText(timeField))
+	appendLogfmtPair(&logfmtBuilder, jsonKeyLevel, messageEntry.messageLevel.levelName)
+
+	if messageEntry.entryCaller != nil {
+		appendLogfmtPair(&logfmtBuilder, jsonKeyCaller, messageEntry.entryCaller.shortFile())
+		appendLogfmtPair(&logfmtBuilder, jsonKeyFunction, messageEntry.entryCaller.callerFunction)
+	}
+
+	appendLogfmtPair(&logfmtBuilder, logfmtKeyMessage, fmt.Sprint(messageEntry.messageParts...))
+
+	for _, entryField := range messageEntry.entryFields {
+		fieldKey := entryField.Key
+
+		switch fieldKey {
+		case logfmtKeyTime, jsonKeyLevel, logfmtKeyMessage, jsonKeyCaller, jsonKeyFunction, jsonKeyStack:
+			fieldKey = jsonKeyFields + fieldKey
+		}
+
+		appendLogfmtPair(&logfmtBuilder, fieldKey, fieldText(entryField))
+	}
+
+	if messageEntry.entryStack != "" {
+		appendLogfmtPair(&logfmtBuilder, jsonKeyStack, messageEntry.entryStack)
+	}
+
+	return logfmtBuilder.String()
+}
+
+// appendLogfmtPair appends a key and value pair separated from the previous pair by a space
+// Values that are empty or contain spaces, quotes, equal signs or control characters are quoted
+func appendLogfmtPair(logfmtBuilder *strings.Builder, logfmtKey string, logfmtValue string) {
+	if logfmtBuilder.Len() > 0 {
+		logfmtBuilder.WriteByte(' ')
+	}
+
+	logfmtBuilder.WriteString(strings.Map(func(keyCharacter rune) rune {
+		if keyCharacter <= ' ' || keyCharacter == '=' || keyCharacter == '"' {
+			return '_'
+		}
+
+		return keyCharacter
+	}, logfmtKey))
+
+	logfmtBuilder.WriteByte('=')
+
+	if logfmtValue == "" || strings.IndexFunc(logfmtValue, func(valueCharacter rune) bool {
+		return valueCharacter <= ' ' || valueCharacter == '=' || valueCharacter == '"' || valueCharacter == 0x7f
+	}) >= 0 {
+		logfmtBuilder.WriteString(strconv.Quote(logfmtValue))
+		return
+	}
+
+	logfmtBuilder.WriteString(logfmtValue)
+}
@@ -0,0 +1,149 @@
+// Logr Sink Adapter
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+)
+
+// LogrSink implements logr.LogSink on top of a log instance
+// Verbosity 0 is written as info, verbosity 1 as debug and higher verbosity as trace
+type LogrSink struct {
+	logInstance *LogInstance // logInstance is the log instance receiving the messages
+	loggerName  string       // loggerName is the name built with WithName
+}
+
+// NewLogrSink returns a logr sink writing through the log instance
+func NewLogrSink(logInstance *LogInstance) *LogrSink {
+	return &LogrSink{logInstance: logInstance}
+}
+
+// NewLogr returns a logr logger writing through the log instance
+func NewLogr(logInstance *LogInstance) logr.Logger {
+	return logr.New(NewLogrSink(logInstance))
+}
+
+// Init skips the frames of logr when recording the caller
+func (logrSink *LogrSink) Init(runtimeInformation logr.RuntimeInfo) {
+	logrSink.logInstance = logrSink.callDepth(runtimeInformation.CallDepth)
+}
+
+// Enabled reports whether messages at the verbosity level are written
+func (logrSink *LogrSink) Enabled(verbosityLevel int) bool {
+	messageLevel := lookupLevel(logrMessageType(verbosityLevel))
+
+	logrSink.logInstance.logLock.Lock()
+	defer logrSink.logInstance.logLock.Unlock()
+
+	return logrSink.logInstance.levelEnabled(messageLevel.levelSeverity)
+}
+
+// Info writes the message with the key and value pairs at the verbosity level
+func (logrSink *LogrSink) Info(verbosityLevel int, messageText string, keysAndValues ...interface{}) {
+	printConfigured(logrSink.logInstance, logrMessageType(verbosityLevel), nil,
+		messageText, logrSink.logrFields(nil, keysAndValues))
+}
+
+// Error writes the message with the error and the key and value pairs as an error message
+func (logrSink *LogrSink) Error(messageError error, messageText string, keysAndValues ...interface{}) {
+	printConfigured(logrSink.logInstance, MessageError, nil,
+		messageText, logrSink.logrFields(messageError, keysAndValues))
+}
+
+// WithValues returns a sink adding the key and value pairs to every message
+func (logrSink *LogrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	childSink := *logrSink
+	childSink.logInstance = logrSink.logInstance.With(nil, logrPairs(keysAndValues)...)
+
+	return &childSink
+}
+
+// WithName returns a sink with the name appended to the logger name
+// Names are joined with a slash and written as the logger field
+func (logrSink *LogrSink) WithName(loggerName string) logr.LogSink {
+	childSink := *logrSink
+
+	if childSink.loggerName != "" {
+		childSink.loggerName += "/"
+	}
+
+	childSink.loggerName += loggerName
+
+	return &childSink
+}
+
+// WithCallDepth returns a sink skipping additional frames when recording the caller
+func (logrSink *LogrSink) WithCallDepth(callDepth int) logr.LogSink {
+	childSink := *logrSink
+	childSink.logInstance = logrSink.callDepth(callDepth)
+
+	return &childSink
+}
+
+// callDepth returns a child log instance skipping additional caller frames
+func (logrSink *LogrSink) callDepth(callDepth int) *LogInstance {
+	childInstance := logrSink.logInstance.With(nil)
+	childInstance.callerSkip += callDepth
+
+	return childInstance
+}
+
+// logrFields returns the logger name, the error and the key and value pairs as fields
+func (logrSink *LogrSink) logrFields(messageError error, keysAndValues []interface{}) []Field {
+	var logFields []Field
+
+	if logrSink.loggerName != "" {
+		logFields = append(logFields, String("logger", logrSink.loggerName))
+	}
+
+	if messageError != nil {
+		logFields = append(logFields, Err(messageError))
+	}
+
+	return append(logFields, logrPairs(keysAndValues)...)
+}
+
+// logrMessageType returns the message type matching the logr verbosity level
+func logrMessageType(verbosityLevel int) string {
+	switch {
+	case verbosityLevel <= 0:
+		return MessageNormal
+
+	case verbosityLevel == 1:
+		return MessageDebug
+
+	default:
+		return MessageTrace
+	}
+}
+
+// logrPairs converts the key and value pairs into fields
+// A key without a value is written with a missing value marker
+func logrPairs(keysAndValues []interface{}) []Field {
+	logFields := make([]Field, 0, (len(keysAndValues)+1)/2)
+
+	for pairIndex := 0; pairIndex < len(keysAndValues); pairIndex += 2 {
+		fieldKey, isString := keysAndValues[pairIndex].(string)
+
+		if !isString {
+			fieldKey = fmt.Sprint(keysAndValues[pairIndex])
+		}
+
+		if pairIndex+1 >= len(keysAndValues) {
+			logFields = append(logFields, String(fieldKey, "(MISSING)"))
+			break
+		}
+
+		logFields = append(logFields, Any(fieldKey, keysAndValues[pairIndex+1]))
+	}
+
+	return logFields
+}
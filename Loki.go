@@ -0,0 +1,147 @@
+// Grafana Loki Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LokiDestination pushes log messages to the push API of Grafana Loki
+// Messages are grouped into streams by their label set and written as JSON lines
+type LokiDestination struct {
+	pushURL      string            // pushURL is the address of the push API, usually ending in /loki/api/v1/push
+	staticLabels map[string]string // staticLabels are attached to every stream
+	labelKeys    []string          // labelKeys are the field keys promoted to stream labels
+	httpSettings HTTPSettings      // httpSettings configures the requests
+	logBatch     *batchSender      // logBatch collects and delivers the encoded messages
+}
+
+// lokiItem is a log message waiting to be pushed to Loki
+type lokiItem struct {
+	streamLabels map[string]string // streamLabels is the label set of the stream of the message
+	streamKey    string            // streamKey identifies the label set
+	messageTime  string            // messageTime is the message time in nanoseconds since the Unix epoch
+	messageLine  string            // messageLine is the JSON encoded message
+}
+
+// lokiStream is a stream of the push API request
+type lokiStream struct {
+	Stream map[string]string `json:"stream"` // Stream is the label set of the stream
+	Values [][2]string       `json:"values"` // Values are the timestamps and lines of the stream
+}
+
+// NewLokiDestination returns a destination pushing log messages to the Loki push API at pushURL
+// Every stream carries the static labels and the level label, and fields named in labelKeys become labels too
+func NewLokiDestination(pushURL string, staticLabels map[string]string, labelKeys []string, httpSettings HTTPSettings) *LokiDestination {
+	lokiDestination := &LokiDestination{
+		pushURL:      pushURL,
+		staticLabels: staticLabels,
+		labelKeys:    labelKeys,
+		httpSettings: httpSettings,
+	}
+
+	lokiDestination.logBatch = newBatchSender(httpSettings.BatchSettings, lokiDestination.deliver)
+
+	return lokiDestination
+}
+
+// WriteEntry encodes the log message with its label set and queues it for delivery
+func (lokiDestination *LokiDestination) WriteEntry(logEntry Entry) error {
+	return lokiDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message with its label set and queues it for delivery
+func (lokiDestination *LokiDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	streamLabels := make(map[string]string, len(lokiDestination.staticLabels)+len(lokiDestination.labelKeys)+1)
+
+	for labelName, labelValue := range lokiDestination.staticLabels {
+		streamLabels[labelName] = labelValue
+	}
+
+	streamLabels["level"] = messageEntry.messageLevel.levelName
+
+	for _, entryField := range messageEntry.entryFields {
+		for _, labelKey := range lokiDestination.labelKeys {
+			if entryField.Key == labelKey {
+				streamLabels[labelKey] = fieldText(entryField)
+			}
+		}
+	}
+
+	var streamKey strings.Builder
+
+	for _, labelName := range sortedLabels(streamLabels) {
+		streamKey.WriteString(labelName + "=" + strconv.Quote(streamLabels[labelName]) + ",")
+	}
+
+	lokiDestination.logBatch.add(logInstance, lokiItem{
+		streamLabels: streamLabels,
+		streamKey:    streamKey.String(),
+		messageTime:  strconv.FormatInt(messageEntry.entryTime.UnixNano(), 10),
+		messageLine:  encodeJSON(jsonTime(logInstance, messageEntry.entryTime), messageEntry),
+	})
+
+	return nil
+}
+
+// Flush delivers every pending message
+func (lokiDestination *LokiDestination) Flush() error {
+	return lokiDestination.logBatch.Flush()
+}
+
+// Close delivers every pending message and stops the background delivery
+func (lokiDestination *LokiDestination) Close() error {
+	return lokiDestination.logBatch.Close()
+}
+
+// deliver pushes the batch with one stream for every label set
+func (lokiDestination *LokiDestination) deliver(batchItems []interface{}) error {
+	var lokiStreams []*lokiStream
+
+	streamIndex := make(map[string]*lokiStream)
+
+	for _, batchItem := range batchItems {
+		pendingItem := batchItem.(lokiItem)
+		itemStream, streamExists := streamIndex[pendingItem.streamKey]
+
+		if !streamExists {
+			itemStream = &lokiStream{Stream: pendingItem.streamLabels}
+			streamIndex[pendingItem.streamKey] = itemStream
+			lokiStreams = append(lokiStreams, itemStream)
+		}
+
+		itemStream.Values = append(itemStream.Values, [2]string{pendingItem.messageTime, pendingItem.messageLine})
+	}
+
+	requestBody, encodeError := json.Marshal(map[string]interface{}{"streams": lokiStreams})
+
+	if encodeError != nil {
+		return encodeError
+	}
+
+	_, postError := postBatch(lokiDestination.pushURL, "application/json", requestBody, lokiDestination.httpSettings)
+
+	return postError
+}
+
+// sortedLabels returns the label names in ascending order
+func sortedLabels(streamLabels map[string]string) []string {
+	labelNames := make([]string, 0, len(streamLabels))
+
+	for labelName := range streamLabels {
+		labelNames = append(labelNames, labelName)
+	}
+
+	sort.Strings(labelNames)
+
+	return labelNames
+}
@@ -0,0 +1,505 @@
+// MessagePack Binary Output Format
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"time"
+)
+
+// errMessagePackInvalid is returned when the decoder reads a value it does not support
+var errMessagePackInvalid = errors.New("the MessagePack stream holds an unsupported value")
+
+// MessagePackDestination writes log messages as MessagePack maps to a writer
+// The maps follow each other without separators, MessagePackDecoder reads them back
+// The keys are the ones of the JSON format and the time is written in nanoseconds since the Unix epoch
+type MessagePackDestination struct {
+	logWriter    io.Writer // logWriter receives the encoded messages
+	minimumLevel Level     // minimumLevel is the minimum severity a message needs to be written
+}
+
+// MessagePackDecoder reads the log messages written by a MessagePackDestination
+type MessagePackDecoder struct {
+	packReader *bufio.Reader // packReader buffers the encoded stream
+}
+
+// NewMessagePackDestination returns a destination writing log messages at or above minimumLevel to logWriter
+// Standard streams are never closed, other writers are closed along with the log instance when they support it
+func NewMessagePackDestination(logWriter io.Writer, minimumLevel Level) *MessagePackDestination {
+	return &MessagePackDestination{logWriter: logWriter, minimumLevel: minimumLevel}
+}
+
+// NewMessagePackDecoder returns a decoder reading log messages from packReader
+func NewMessagePackDecoder(packReader io.Reader) *MessagePackDecoder {
+	return &MessagePackDecoder{packReader: bufio.NewReader(packReader)}
+}
+
+// WriteEntry encodes the log message as a MessagePack map and writes it
+func (packDestination *MessagePackDestination) WriteEntry(logEntry Entry) error {
+	return packDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message as a MessagePack map and writes it
+func (packDestination *MessagePackDestination) writeEntry(_ *LogInstance, messageEntry logEntry) error {
+	if messageEntry.messageLevel.levelSeverity < packDestination.minimumLevel {
+		return nil
+	}
+
+	_, writeError := packDestination.logWriter.Write(encodeMessagePack(messageEntry))
+
+	return writeError
+}
+
+// Flush flushes and syncs the writer when it supports it
+func (packDestination *MessagePackDestination) Flush() error {
+	return flushOutput(packDestination.logWriter)
+}
+
+// Close flushes the writer and closes it unless it is a standard stream
+func (packDestination *MessagePackDestination) Close() error {
+	flushError := flushOutput(packDestination.logWriter)
+
+	if logCloser, isCloser := packDestination.logWriter.(io.Closer); isCloser && !isStandardStream(packDestination.logWriter) {
+		if closeError := logCloser.Close(); flushError == nil {
+			flushError = closeError
+		}
+	}
+
+	return flushError
+}
+
+// Decode reads the next log message as a map from the keys to their values
+// It returns io.EOF once every message was read
+func (packDecoder *MessagePackDecoder) Decode() (map[string]interface{}, error) {
+	if _, peekError := packDecoder.packReader.Peek(1); peekError != nil {
+		return nil, peekError
+	}
+
+	decodedValue, decodeError := packDecoder.decodeValue()
+
+	if decodeError != nil {
+		if decodeError == io.EOF {
+			decodeError = io.ErrUnexpectedEOF
+		}
+
+		return nil, decodeError
+	}
+
+	decodedMessage, isMap := decodedValue.(map[string]interface{})
+
+	if !isMap {
+		return nil, errMessagePackInvalid
+	}
+
+	return decodedMessage, nil
+}
+
+// encodeMessagePack encodes the message time, level, content and fields as a MessagePack map
+// The keys and their order are the ones of the JSON format
+func encodeMessagePack(messageEntry logEntry) []byte {
+	pairCount := 3 + len(messageEntry.entryFields)
+
+	if messageEntry.entryCaller != nil {
+		pairCount += 2
+	}
+
+	if messageEntry.entryStack != "" {
+		pairCount++
+	}
+
+	packBuffer := appendPackHeader(nil, 0x80, 0xde, 0xdf, pairCount)
+
+	packBuffer = appendPackString(packBuffer, jsonKeyTime)
+	packBuffer = appendPackInteger(packBuffer, messageEntry.entryTime.UnixNano())
+	packBuffer = appendPackString(packBuffer, jsonKeyLevel)
+	packBuffer = appendPackString(packBuffer, messageEntry.messageLevel.levelName)
+
+	if messageEntry.entryCaller != nil {
+		packBuffer = appendPackString(packBuffer, jsonKeyCaller)
+		packBuffer = appendPackString(packBuffer, messageEntry.entryCaller.shortFile())
+		packBuffer = appendPackString(packBuffer, jsonKeyFunction)
+		packBuffer = appendPackString(packBuffer, messageEntry.entryCaller.callerFunction)
+	}
+
+	packBuffer = appendPackString(packBuffer, jsonKeyMessage)
+	packBuffer = appendPackString(packBuffer, fmt.Sprint(messageEntry.messageParts...))
+
+	for _, entryField := range messageEntry.entryFields {
+		fieldKey := entryField.Key
+
+		switch fieldKey {
+		case jsonKeyTime, jsonKeyLevel, jsonKeyMessage, jsonKeyCaller, jsonKeyFunction, jsonKeyStack:
+			fieldKey = jsonKeyFields + fieldKey
+		}
+
+		packBuffer = appendPackString(packBuffer, fieldKey)
+
+		switch entryField.valueType {
+		case fieldString:
+			packBuffer = appendPackString(packBuffer, entryField.stringValue)
+
+		case fieldInteger:
+			packBuffer = appendPackInteger(packBuffer, entryField.integerValue)
+
+		default:
+			packBuffer = appendPackValue(packBuffer, normalizeValue(entryField.Value(), 0), 0)
+		}
+	}
+
+	if messageEntry.entryStack != "" {
+		packBuffer = appendPackString(packBuffer, jsonKeyStack)
+		packBuffer = appendPackString(packBuffer, messageEntry.entryStack)
+	}
+
+	return packBuffer
+}
+
+// appendPackValue appends a normalized value in its MessagePack form
+// Values without a MessagePack form are written as their text
+func appendPackValue(packBuffer []byte, packValue interface{}, nestingDepth int) []byte {
+	switch typedValue := packValue.(type) {
+	case nil:
+		return append(packBuffer, 0xc0)
+
+	case bool:
+		if typedValue {
+			return append(packBuffer, 0xc3)
+		}
+
+		return append(packBuffer, 0xc2)
+
+	case string:
+		return appendPackString(packBuffer, typedValue)
+
+	case []byte:
+		packBuffer = appendPackHeader(packBuffer, 0, 0xc5, 0xc6, len(typedValue))
+		return append(packBuffer, typedValue...)
+
+	case time.Time:
+		return appendPackString(packBuffer, typedValue.Format(time.RFC3339Nano))
+
+	case json.Marshaler:
+		var decodedValue interface{}
+
+		if encodedValue, encodeError := typedValue.MarshalJSON(); encodeError == nil &&
+			json.Unmarshal(encodedValue, &decodedValue) == nil && nestingDepth < maximumNestingDepth {
+			return appendPackValue(packBuffer, decodedValue, nestingDepth+1)
+		}
+
+	case encoding.TextMarshaler:
+		if encodedValue, encodeError := typedValue.MarshalText(); encodeError == nil {
+			return appendPackString(packBuffer, string(encodedValue))
+		}
+
+	case []interface{}:
+		packBuffer = appendPackHeader(packBuffer, 0x90, 0xdc, 0xdd, len(typedValue))
+
+		for _, itemValue := range typedValue {
+			packBuffer = appendPackValue(packBuffer, itemValue, nestingDepth+1)
+		}
+
+		return packBuffer
+
+	case map[string]interface{}:
+		packBuffer = appendPackHeader(packBuffer, 0x80, 0xde, 0xdf, len(typedValue))
+
+		for _, mapKey := range sortedKeys(typedValue) {
+			packBuffer = appendPackString(packBuffer, mapKey)
+			packBuffer = appendPackValue(packBuffer, typedValue[mapKey], nestingDepth+1)
+		}
+
+		return packBuffer
+	}
+
+	reflectValue := reflect.ValueOf(packValue)
+
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendPackInteger(packBuffer, reflectValue.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if reflectValue.Uint() <= math.MaxInt64 {
+			return appendPackInteger(packBuffer, int64(reflectValue.Uint()))
+		}
+
+		return binary.BigEndian.AppendUint64(append(packBuffer, 0xcf), reflectValue.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(packBuffer, 0xcb), math.Float64bits(reflectValue.Float()))
+
+	case reflect.String:
+		return appendPackString(packBuffer, reflectValue.String())
+	}
+
+	return appendPackString(packBuffer, fmt.Sprint(packValue))
+}
+
+// appendPackInteger appends an integer in its shortest MessagePack form
+func appendPackInteger(packBuffer []byte, integerValue int64) []byte {
+	switch {
+	case integerValue >= 0 && integerValue <= 0x7f:
+		return append(packBuffer, byte(integerValue))
+
+	case integerValue < 0 && integerValue >= -32:
+		return append(packBuffer, byte(integerValue))
+
+	case integerValue >= math.MinInt32 && integerValue <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(packBuffer, 0xd2), uint32(integerValue))
+
+	default:
+		return binary.BigEndian.AppendUint64(append(packBuffer, 0xd3), uint64(integerValue))
+	}
+}
+
+// appendPackString appends a string in its shortest MessagePack form
+func appendPackString(packBuffer []byte, stringValue string) []byte {
+	if len(stringValue) <= 255 && len(stringValue) > 31 {
+		packBuffer = append(packBuffer, 0xd9, byte(len(stringValue)))
+	} else {
+		packBuffer = appendPackHeader(packBuffer, 0xa0, 0xda, 0xdb, len(stringValue))
+	}
+
+	return append(packBuffer, stringValue...)
+}
+
+// appendPackHeader appends the header of a string, binary, array or map of the length
+// fixCode is the code of the short form holding the length itself, zero when the kind has no short form
+func appendPackHeader(packBuffer []byte, fixCode byte, shortCode byte, longCode byte, valueLength int) []byte {
+	fixLimit := 15
+
+	if fixCode == 0xa0 {
+		fixLimit = 31
+	}
+
+	switch {
+	case fixCode != 0 && valueLength <= fixLimit:
+		return append(packBuffer, fixCode|byte(valueLength))
+
+	case fixCode == 0 && valueLength <= math.MaxUint8:
+		return append(packBuffer, 0xc4, byte(valueLength))
+
+	case valueLength <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(packBuffer, shortCode), uint16(valueLength))
+
+	default:
+		return binary.BigEndian.AppendUint32(append(packBuffer, longCode), uint32(valueLength))
+	}
+}
+
+// decodeValue reads a single MessagePack value
+// Integers are returned as int64, floats as float64, binaries as []byte and maps with string keys
+func (packDecoder *MessagePackDecoder) decodeValue() (interface{}, error) {
+	typeCode, readError := packDecoder.packReader.ReadByte()
+
+	if readError != nil {
+		return nil, readError
+	}
+
+	switch {
+	case typeCode <= 0x7f:
+		return int64(typeCode), nil
+
+	case typeCode >= 0xe0:
+		return int64(int8(typeCode)), nil
+
+	case typeCode&0xe0 == 0xa0:
+		return packDecoder.decodeString(int(typeCode & 0x1f))
+
+	case typeCode&0xf0 == 0x90:
+		return packDecoder.decodeArray(int(typeCode & 0x0f))
+
+	case typeCode&0xf0 == 0x80:
+		return packDecoder.decodeMap(int(typeCode & 0x0f))
+	}
+
+	switch typeCode {
+	case 0xc0:
+		return nil, nil
+
+	case 0xc2:
+		return false, nil
+
+	case 0xc3:
+		return true, nil
+
+	case 0xc4, 0xc5, 0xc6:
+		valueLength, lengthError := packDecoder.decodeLength(typeCode - 0xc4)
+
+		if lengthError != nil {
+			return nil, lengthError
+		}
+
+		return packDecoder.readBytes(valueLength)
+
+	case 0xca:
+		valueBytes, valueError := packDecoder.readBytes(4)
+
+		if valueError != nil {
+			return nil, valueError
+		}
+
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(valueBytes))), nil
+
+	case 0xcb:
+		valueBytes, valueError := packDecoder.readBytes(8)
+
+		if valueError != nil {
+			return nil, valueError
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(valueBytes)), nil
+
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		valueBytes, valueError := packDecoder.readBytes(1 << (typeCode - 0xcc))
+
+		if valueError != nil {
+			return nil, valueError
+		}
+
+		unsignedValue := unpackUnsigned(valueBytes)
+
+		if unsignedValue > math.MaxInt64 {
+			return unsignedValue, nil
+		}
+
+		return int64(unsignedValue), nil
+
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		valueBytes, valueError := packDecoder.readBytes(1 << (typeCode - 0xd0))
+
+		if valueError != nil {
+			return nil, valueError
+		}
+
+		signShift := 64 - 8*len(valueBytes)
+
+		return int64(unpackUnsigned(valueBytes)<<signShift) >> signShift, nil
+
+	case 0xd9, 0xda, 0xdb:
+		valueLength, lengthError := packDecoder.decodeLength(typeCode - 0xd9)
+
+		if lengthError != nil {
+			return nil, lengthError
+		}
+
+		return packDecoder.decodeString(valueLength)
+
+	case 0xdc, 0xdd:
+		valueLength, lengthError := packDecoder.decodeLength(typeCode - 0xdc + 1)
+
+		if lengthError != nil {
+			return nil, lengthError
+		}
+
+		return packDecoder.decodeArray(valueLength)
+
+	case 0xde, 0xdf:
+		valueLength, lengthError := packDecoder.decodeLength(typeCode - 0xde + 1)
+
+		if lengthError != nil {
+			return nil, lengthError
+		}
+
+		return packDecoder.decodeMap(valueLength)
+	}
+
+	return nil, errMessagePackInvalid
+}
+
+// decodeLength reads a length of one, two or four bytes for the size index 0, 1 or 2
+func (packDecoder *MessagePackDecoder) decodeLength(sizeIndex byte) (int, error) {
+	lengthBytes, readError := packDecoder.readBytes(1 << sizeIndex)
+
+	if readError != nil {
+		return 0, readError
+	}
+
+	return int(unpackUnsigned(lengthBytes)), nil
+}
+
+// decodeString reads a string of the length
+func (packDecoder *MessagePackDecoder) decodeString(valueLength int) (interface{}, error) {
+	valueBytes, readError := packDecoder.readBytes(valueLength)
+
+	if readError != nil {
+		return nil, readError
+	}
+
+	return string(valueBytes), nil
+}
+
+// decodeArray reads an array of the length
+func (packDecoder *MessagePackDecoder) decodeArray(valueLength int) (interface{}, error) {
+	decodedArray := make([]interface{}, 0, min(valueLength, 1024))
+
+	for itemIndex := 0; itemIndex < valueLength; itemIndex++ {
+		itemValue, decodeError := packDecoder.decodeValue()
+
+		if decodeError != nil {
+			return nil, decodeError
+		}
+
+		decodedArray = append(decodedArray, itemValue)
+	}
+
+	return decodedArray, nil
+}
+
+// decodeMap reads a map of the length, keys that are not strings get their text form
+func (packDecoder *MessagePackDecoder) decodeMap(valueLength int) (interface{}, error) {
+	decodedMap := make(map[string]interface{}, min(valueLength, 1024))
+
+	for pairIndex := 0; pairIndex < valueLength; pairIndex++ {
+		mapKey, keyError := packDecoder.decodeValue()
+
+		if keyError != nil {
+			return nil, keyError
+		}
+
+		mapValue, valueError := packDecoder.decodeValue()
+
+		if valueError != nil {
+			return nil, valueError
+		}
+
+		decodedMap[fmt.Sprint(mapKey)] = mapValue
+	}
+
+	return decodedMap, nil
+}
+
+// readBytes reads exactly the number of bytes
+func (packDecoder *MessagePackDecoder) readBytes(valueLength int) ([]byte, error) {
+	valueBytes := make([]byte, valueLength)
+
+	if _, readError := io.ReadFull(packDecoder.packReader, valueBytes); readError != nil {
+		return nil, readError
+	}
+
+	return valueBytes, nil
+}
+
+// unpackUnsigned returns the big endian unsigned integer held by the bytes
+func unpackUnsigned(valueBytes []byte) uint64 {
+	var unsignedValue uint64
+
+	for _, valueByte := range valueBytes {
+		unsignedValue = unsignedValue<<8 | uint64(valueByte)
+	}
+
+	return unsignedValue
+}
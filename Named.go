@@ -0,0 +1,223 @@
+// Named Logger Registry
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// loggerKey is the key of the field holding the name of a named logger
+const loggerKey string = "logger"
+
+// loggerRegistry holds the named loggers of a log instance and its children along with the level overrides
+type loggerRegistry struct {
+	registryLock   sync.Mutex              // registryLock guards the named loggers and the overrides
+	namedLoggers   map[string]*namedLogger // namedLoggers maps every full name to its logger
+	levelOverrides []levelOverride         // levelOverrides are the levels set with SetLevelFor in the order they were set
+	quietDebug     atomic.Bool             // quietDebug hides debug and trace messages of unnamed loggers while a debug selector is set
+}
+
+// namedLogger is a log instance registered under a dotted name
+type namedLogger struct {
+	loggerInstance *LogInstance          // loggerInstance is the child logger returned by Named
+	overrideLevel  atomic.Pointer[Level] // overrideLevel is the level of the most specific matching override, nil without one
+	quietDebug     atomic.Bool           // quietDebug hides debug and trace messages when the most specific match excludes the logger
+}
+
+// levelOverride is a level applied to the named loggers matching a pattern
+type levelOverride struct {
+	namePattern     string // namePattern selects the named loggers
+	overrideLevel   Level  // overrideLevel is the minimum severity of the matching loggers
+	fromSelector    bool   // fromSelector marks the overrides of a debug selector, replaced by the next selector
+	excludeOverride bool   // excludeOverride hides debug and trace messages of the matching loggers
+}
+
+// Named returns the child logger registered under the name, appended to the name of the log instance with a dot
+// The name is written as the logger field, and the same child logger is returned for the same full name
+// The level of a named logger follows the most specific override of SetLevelFor matching its name
+func (logInstance *LogInstance) Named(loggerName string) *LogInstance {
+	fullName := loggerName
+
+	if logInstance.loggerName != "" {
+		fullName = logInstance.loggerName + "." + loggerName
+	}
+
+	logRegistry := logInstance.logRegistry
+
+	logRegistry.registryLock.Lock()
+	defer logRegistry.registryLock.Unlock()
+
+	if registeredLogger, isRegistered := logRegistry.namedLoggers[fullName]; isRegistered {
+		return registeredLogger.loggerInstance
+	}
+
+	childInstance := logInstance.With(nil)
+	childInstance.boundFields = append(withoutKeys(childInstance.boundFields, []string{loggerKey}), String(loggerKey, fullName))
+	childInstance.loggerName = fullName
+	childInstance.namedNode = &namedLogger{loggerInstance: childInstance}
+	childInstance.namedNode.resolve(fullName, logRegistry.levelOverrides)
+
+	logRegistry.namedLoggers[fullName] = childInstance.namedNode
+
+	return childInstance
+}
+
+// SetLevelFor sets the level of the named loggers matching the pattern, including the ones created later
+// A pattern is a dotted name whose segments may be *: db applies to db and every logger below it,
+// db.* only to the loggers below db and * to every named logger
+// When several patterns match, the one with the most literal segments and then the most segments wins
+func (logInstance *LogInstance) SetLevelFor(namePattern string, logLevel Level) {
+	logRegistry := logInstance.logRegistry
+
+	logRegistry.registryLock.Lock()
+	defer logRegistry.registryLock.Unlock()
+
+	logRegistry.levelOverrides = append(logRegistry.levelOverrides, levelOverride{namePattern: namePattern, overrideLevel: logLevel})
+	logRegistry.resolveAll()
+}
+
+// WithDebugSelector enables debug messages for the named loggers matching the selector
+// See SetDebugSelector for the syntax of the selector
+func WithDebugSelector(debugSelector string) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.SetDebugSelector(debugSelector)
+	}
+}
+
+// SetDebugSelector enables debug messages only for the named loggers matching the debug selector,
+// a comma separated list of names in the style of GOLOG_DEBUG=api:*,db:query
+// Colons separate the segments like dots, a * segment matches any segment and a name preceded by - is excluded
+// Debug and trace messages of every other logger are hidden while the selector is set, overrides of SetLevelFor
+// still apply, and the selector replaces the previous one or clears it when empty
+func (logInstance *LogInstance) SetDebugSelector(debugSelector string) {
+	logRegistry := logInstance.logRegistry
+
+	logRegistry.registryLock.Lock()
+	defer logRegistry.registryLock.Unlock()
+
+	keptOverrides := logRegistry.levelOverrides[:0:0]
+
+	for _, existingOverride := range logRegistry.levelOverrides {
+		if !existingOverride.fromSelector {
+			keptOverrides = append(keptOverrides, existingOverride)
+		}
+	}
+
+	selectorNames := strings.FieldsFunc(debugSelector, func(selectorChar rune) bool {
+		return selectorChar == ',' || selectorChar == ' '
+	})
+
+	// Exclude every logger first so any selected name wins
+
+	if len(selectorNames) > 0 {
+		keptOverrides = append(keptOverrides, levelOverride{namePattern: "*", fromSelector: true, excludeOverride: true})
+	}
+
+	for _, selectorName := range selectorNames {
+		selectorOverride := levelOverride{overrideLevel: LevelDebug, fromSelector: true}
+
+		if strings.HasPrefix(selectorName, "-") {
+			selectorName, selectorOverride.excludeOverride = selectorName[1:], true
+		}
+
+		selectorOverride.namePattern = strings.ReplaceAll(selectorName, ":", ".")
+		keptOverrides = append(keptOverrides, selectorOverride)
+	}
+
+	logRegistry.levelOverrides = keptOverrides
+	logRegistry.quietDebug.Store(len(selectorNames) > 0)
+	logRegistry.resolveAll()
+}
+
+// resolveAll resolves the level of every named logger again after the overrides changed
+func (logRegistry *loggerRegistry) resolveAll() {
+	for fullName, registeredLogger := range logRegistry.namedLoggers {
+		registeredLogger.resolve(fullName, logRegistry.levelOverrides)
+	}
+}
+
+// minimumLevel returns the minimum severity a message needs to be written
+// The override of a named logger replaces the level of the log instance, and an excluded logger writes no debug messages
+func (logInstance *LogInstance) minimumLevel() Level {
+	quietDebug := logInstance.logRegistry.quietDebug.Load()
+
+	if logInstance.namedNode != nil {
+		if overrideLevel := logInstance.namedNode.overrideLevel.Load(); overrideLevel != nil {
+			return *overrideLevel
+		}
+
+		quietDebug = logInstance.namedNode.quietDebug.Load()
+	}
+
+	instanceLevel := Level(logInstance.logLevel.Load())
+
+	if quietDebug && instanceLevel < LevelNormal {
+		return LevelNormal
+	}
+
+	return instanceLevel
+}
+
+// resolve selects the level of the most specific override matching the full name, later overrides win ties
+// An excluding override hides the debug messages of the named logger instead of setting its level
+func (registeredLogger *namedLogger) resolve(fullName string, levelOverrides []levelOverride) {
+	var selectedOverride *levelOverride
+	selectedRank := -1
+
+	for overrideIndex := range levelOverrides {
+		isMatch, matchRank := matchLoggerName(levelOverrides[overrideIndex].namePattern, fullName)
+
+		if isMatch && matchRank >= selectedRank {
+			selectedOverride, selectedRank = &levelOverrides[overrideIndex], matchRank
+		}
+	}
+
+	registeredLogger.quietDebug.Store(selectedOverride != nil && selectedOverride.excludeOverride)
+
+	if selectedOverride == nil || selectedOverride.excludeOverride {
+		registeredLogger.overrideLevel.Store(nil)
+		return
+	}
+
+	overrideLevel := selectedOverride.overrideLevel
+	registeredLogger.overrideLevel.Store(&overrideLevel)
+}
+
+// matchLoggerName reports whether the pattern matches the full name along with the rank of the match
+// The rank grows with the literal segments first and the segments of the pattern second
+func matchLoggerName(namePattern string, fullName string) (bool, int) {
+	if namePattern == "*" {
+		return true, 0
+	}
+
+	patternSegments := strings.Split(namePattern, ".")
+	nameSegments := strings.Split(fullName, ".")
+
+	if len(patternSegments) > len(nameSegments) {
+		return false, 0
+	}
+
+	literalSegments := 0
+
+	for segmentIndex, patternSegment := range patternSegments {
+		if patternSegment == "*" {
+			continue
+		}
+
+		if patternSegment != nameSegments[segmentIndex] {
+			return false, 0
+		}
+
+		literalSegments++
+	}
+
+	return true, literalSegments<<16 + len(patternSegments)
+}
@@ -0,0 +1,251 @@
+// Network Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNetworkTimeout time.Duration = 5 * time.Second // defaultNetworkTimeout is the default timeout of connecting and writing
+	reconnectDelay        time.Duration = time.Second     // reconnectDelay is the minimum period between two connection attempts
+)
+
+// errNetworkUnavailable is returned when the collector cannot be reached and no spill file is configured
+var errNetworkUnavailable = errors.New("the log collector is unreachable")
+
+// networkWriter streams complete log lines to a remote collector
+// Lines that cannot be sent are kept in the spill file and sent first once the connection is back
+type networkWriter struct {
+	networkLock       sync.Mutex    // networkLock guards the connection and the pending output
+	networkType       string        // networkType is the network of the collector, such as tcp or udp
+	networkAddress    string        // networkAddress is the address of the collector
+	networkTimeout    time.Duration // networkTimeout limits every connection attempt and write
+	spillPath         string        // spillPath is the file holding lines written while the collector is unreachable
+	networkConnection net.Conn      // networkConnection is the open connection, nil while disconnected
+	pendingOutput     []byte        // pendingOutput holds the start of a line not yet complete
+	lastAttempt       time.Time     // lastAttempt is the time of the last connection attempt
+	connectionLost    bool          // connectionLost reports whether the loss of the collector was reported
+
+	reportDiagnostic func(diagnosticComponent string, diagnosticMessage string, diagnosticError error) // reportDiagnostic reports connections lost and restored
+}
+
+// InitializeNetwork the log data with the provided network destination
+// The file output is streamed line by line to the collector at networkAddress over networkType
+// The connection is opened lazily and reopened automatically after a failure
+func InitializeNetwork(networkType string, networkAddress string, logOptions ...Option) *LogInstance {
+	logInstance := newInstance()
+
+	for _, logOption := range logOptions {
+		logOption(logInstance)
+	}
+
+	networkTimeout := logInstance.networkTimeout
+
+	if networkTimeout <= 0 {
+		networkTimeout = defaultNetworkTimeout
+	}
+
+	logInstance.logOutput.logWriter = logInstance.bufferOutput(&networkWriter{
+		networkType:      networkType,
+		networkAddress:   networkAddress,
+		networkTimeout:   networkTimeout,
+		spillPath:        logInstance.spillPath,
+		reportDiagnostic: logInstance.diagnose,
+	})
+	logInstance.startQueue()
+
+	return logInstance
+}
+
+// WithNetworkTimeout limits how long connecting to the collector and every write may take
+func WithNetworkTimeout(networkTimeout time.Duration) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.networkTimeout = networkTimeout
+	}
+}
+
+// WithSpillFile keeps the lines written while the collector is unreachable in the file at spillPath
+// The kept lines are sent before any new line once the connection is back
+func WithSpillFile(spillPath string) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.spillPath = spillPath
+	}
+}
+
+// Write sends every complete line to the collector and keeps the rest until its line is complete
+func (logNetwork *networkWriter) Write(writeContent []byte) (int, error) {
+	logNetwork.networkLock.Lock()
+	defer logNetwork.networkLock.Unlock()
+
+	logNetwork.pendingOutput = append(logNetwork.pendingOutput, writeContent...)
+	lineEnd := bytes.LastIndexByte(logNetwork.pendingOutput, '\n')
+
+	if lineEnd < 0 {
+		return len(writeContent), nil
+	}
+
+	completeLines := append([]byte{}, logNetwork.pendingOutput[:lineEnd+1]...)
+	logNetwork.pendingOutput = append(logNetwork.pendingOutput[:0], logNetwork.pendingOutput[lineEnd+1:]...)
+
+	return len(writeContent), logNetwork.send(completeLines)
+}
+
+// Close sends the incomplete line and closes the connection
+func (logNetwork *networkWriter) Close() error {
+	logNetwork.networkLock.Lock()
+	defer logNetwork.networkLock.Unlock()
+
+	var sendError error
+
+	if len(logNetwork.pendingOutput) > 0 {
+		sendError = logNetwork.send(append(logNetwork.pendingOutput, '\n'))
+		logNetwork.pendingOutput = nil
+	}
+
+	logNetwork.disconnect()
+
+	return sendError
+}
+
+// send writes the lines to the collector, spilling them to disk if the collector is unreachable
+func (logNetwork *networkWriter) send(completeLines []byte) error {
+	if logNetwork.connect() == nil {
+		sendError := logNetwork.replaySpill()
+
+		if sendError == nil {
+			sendError = logNetwork.transmit(completeLines)
+		}
+
+		if sendError == nil {
+			return nil
+		}
+
+		logNetwork.loseConnection("lost the connection to the log collector at "+logNetwork.networkAddress, sendError)
+		logNetwork.disconnect()
+	}
+
+	if logNetwork.spillPath == "" {
+		return errNetworkUnavailable
+	}
+
+	spillFile, openError := os.OpenFile(logNetwork.spillPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+
+	if openError != nil {
+		return openError
+	}
+
+	_, writeError := spillFile.Write(completeLines)
+
+	if closeError := spillFile.Close(); writeError == nil {
+		writeError = closeError
+	}
+
+	return writeError
+}
+
+// connect opens the connection to the collector unless it is open already
+// Connection attempts are made at most once every reconnect delay
+func (logNetwork *networkWriter) connect() error {
+	if logNetwork.networkConnection != nil {
+		return nil
+	}
+
+	if time.Since(logNetwork.lastAttempt) < reconnectDelay {
+		return errNetworkUnavailable
+	}
+
+	logNetwork.lastAttempt = time.Now()
+
+	networkConnection, dialError := net.DialTimeout(logNetwork.networkType, logNetwork.networkAddress, logNetwork.networkTimeout)
+
+	if dialError != nil {
+		logNetwork.loseConnection("unable to connect to the log collector at "+logNetwork.networkAddress, dialError)
+		return dialError
+	}
+
+	logNetwork.networkConnection = networkConnection
+
+	if logNetwork.connectionLost {
+		logNetwork.connectionLost = false
+		logNetwork.reportDiagnostic(DiagnosticNetwork, "reconnected to the log collector at "+logNetwork.networkAddress, nil)
+	}
+
+	return nil
+}
+
+// loseConnection reports the first failure of the collector until it is reachable again
+func (logNetwork *networkWriter) loseConnection(diagnosticMessage string, connectionError error) {
+	if logNetwork.connectionLost {
+		return
+	}
+
+	logNetwork.connectionLost = true
+	logNetwork.reportDiagnostic(DiagnosticNetwork, diagnosticMessage, connectionError)
+}
+
+// disconnect closes the connection so the next write reconnects
+func (logNetwork *networkWriter) disconnect() {
+	if logNetwork.networkConnection != nil {
+		logNetwork.networkConnection.Close()
+		logNetwork.networkConnection = nil
+	}
+}
+
+// replaySpill sends the lines kept in the spill file and removes it
+func (logNetwork *networkWriter) replaySpill() error {
+	if logNetwork.spillPath == "" {
+		return nil
+	}
+
+	spilledLines, readError := os.ReadFile(logNetwork.spillPath)
+
+	if errors.Is(readError, os.ErrNotExist) || (readError == nil && len(spilledLines) == 0) {
+		return nil
+	}
+
+	if readError != nil {
+		return readError
+	}
+
+	if transmitError := logNetwork.transmit(spilledLines); transmitError != nil {
+		return transmitError
+	}
+
+	return os.Remove(logNetwork.spillPath)
+}
+
+// transmit writes the lines to the open connection within the network timeout
+// Datagram networks receive every line as a separate packet
+func (logNetwork *networkWriter) transmit(completeLines []byte) error {
+	logNetwork.networkConnection.SetWriteDeadline(time.Now().Add(logNetwork.networkTimeout))
+
+	if !strings.HasPrefix(logNetwork.networkType, "udp") && !strings.HasPrefix(logNetwork.networkType, "unixgram") {
+		_, writeError := logNetwork.networkConnection.Write(completeLines)
+		return writeError
+	}
+
+	for _, logLine := range bytes.SplitAfter(completeLines, []byte{'\n'}) {
+		if len(logLine) == 0 {
+			continue
+		}
+
+		if _, writeError := logNetwork.networkConnection.Write(logLine); writeError != nil {
+			return writeError
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// Initialize Options
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// Option configures a log instance during initialization
+type Option func(logInstance *LogInstance)
+
+// WithAppend opens the log file in append mode instead of truncating it
+// Existing log history is kept across restarts
+func WithAppend() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.appendOutput = true
+	}
+}
+
+// WithColor selects whether the leveled methods color the terminal output
+func WithColor(needTerminalColoredOutput bool) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.colorOutput = needTerminalColoredOutput
+	}
+}
+
+// WithTerminal selects whether the leveled methods write to the terminal
+func WithTerminal(needTerminalOutput bool) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.terminalOutput = needTerminalOutput
+	}
+}
+
+// WithFile selects whether the leveled methods write to the log file
+func WithFile(needFileOutput bool) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.fileOutput = needFileOutput
+	}
+}
+
+// WithLevel sets the minimum severity a message needs to be written
+func WithLevel(logLevel Level) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logLevel.Store(int64(logLevel))
+	}
+}
+
+// WithTimeFormat sets the layout used to format the message time
+// The layout follows the reference time of the time package
+func WithTimeFormat(timeFormat string) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.timeFormat = timeFormat
+	}
+}
@@ -0,0 +1,69 @@
+// Output Destination Settings
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"io"
+	"os"
+)
+
+// WithStderrLevel writes terminal messages at or above minimumLevel to the standard error stream
+// Lower messages go to the standard output, by default warnings and above go to the standard error
+// A level above LevelFatal keeps every terminal message on the standard output
+func WithStderrLevel(minimumLevel Level) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.stderrLevel = minimumLevel
+	}
+}
+
+// SetTerminalOutput selects whether the leveled methods write to the terminal
+func (logInstance *LogInstance) SetTerminalOutput(needTerminalOutput bool) {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	logInstance.terminalOutput = needTerminalOutput
+}
+
+// SetFileOutput selects whether the leveled methods write to the log file
+func (logInstance *LogInstance) SetFileOutput(needFileOutput bool) {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	logInstance.fileOutput = needFileOutput
+}
+
+// SetColorOutput selects whether the leveled methods color the terminal output
+func (logInstance *LogInstance) SetColorOutput(needTerminalColoredOutput bool) {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	logInstance.colorOutput = needTerminalColoredOutput
+}
+
+// printConfigured writes the log message to the destinations configured on the log instance
+func printConfigured(logInstance *LogInstance, messageType string,
+	jsonContent map[string]interface{}, messageContent ...interface{}) {
+	logInstance.logLock.Lock()
+	needFileOutput := logInstance.fileOutput
+	needTerminalOutput := logInstance.terminalOutput
+	needTerminalColoredOutput := logInstance.colorOutput
+	logInstance.logLock.Unlock()
+
+	printOutPut(logInstance, needFileOutput, needTerminalOutput, needTerminalColoredOutput,
+		messageType, jsonContent, messageContent...)
+}
+
+// terminalWriter returns the standard stream receiving terminal messages of the selected severity
+func (logInstance *LogInstance) terminalWriter(levelSeverity Level) io.Writer {
+	if levelSeverity >= logInstance.stderrLevel {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
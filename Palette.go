@@ -0,0 +1,193 @@
+// Extended Terminal Colors
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// colorDepth is the number of colors a terminal displays
+type colorDepth int
+
+const (
+	colorDepthBasic   colorDepth = iota // colorDepthBasic represents terminals limited to the 16 basic colors
+	colorDepthPalette                   // colorDepthPalette represents terminals displaying the 256 color palette
+	colorDepthTrue                      // colorDepthTrue represents terminals displaying 24-bit colors
+)
+
+const (
+	paletteColorPrefix string = "\x1b[38;5;" // paletteColorPrefix starts the escape sequence of a palette color
+	trueColorPrefix    string = "\x1b[38;2;" // trueColorPrefix starts the escape sequence of a 24-bit color
+)
+
+// Color256 returns the escape sequence selecting the color of the 256 color palette at paletteIndex
+// Terminals limited to the basic colors get the closest basic color
+func Color256(paletteIndex uint8) string {
+	return paletteColorPrefix + strconv.Itoa(int(paletteIndex)) + "m"
+}
+
+// ColorRGB returns the escape sequence selecting the 24-bit color with the red, green and blue components
+// Terminals without 24-bit colors get the closest palette or basic color
+func ColorRGB(redValue uint8, greenValue uint8, blueValue uint8) string {
+	return trueColorPrefix + strconv.Itoa(int(redValue)) + ";" + strconv.Itoa(int(greenValue)) + ";" +
+		strconv.Itoa(int(blueValue)) + "m"
+}
+
+// terminalColorDepth returns the number of colors the terminal displays according to the environment
+func terminalColorDepth() colorDepth {
+	colorTerm := os.Getenv("COLORTERM")
+	terminalName := os.Getenv("TERM")
+
+	switch {
+	case colorTerm == "truecolor" || colorTerm == "24bit" || os.Getenv("WT_SESSION") != "" ||
+		strings.HasSuffix(terminalName, "-direct"):
+		return colorDepthTrue
+
+	case strings.Contains(terminalName, "256color"):
+		return colorDepthPalette
+
+	default:
+		return colorDepthBasic
+	}
+}
+
+// degradeColor replaces palette and 24-bit colors with the closest color the terminal displays
+// Other escape sequences are returned unchanged
+func degradeColor(colorCode string) string {
+	isPalette := strings.HasPrefix(colorCode, paletteColorPrefix)
+	isTrue := strings.HasPrefix(colorCode, trueColorPrefix)
+
+	if !isPalette && !isTrue {
+		return colorCode
+	}
+
+	displayedDepth := terminalColorDepth()
+
+	if displayedDepth == colorDepthTrue || (isPalette && displayedDepth == colorDepthPalette) {
+		return colorCode
+	}
+
+	var colorComponents []int
+
+	for _, colorComponent := range strings.Split(strings.TrimSuffix(colorCode[len(paletteColorPrefix):], "m"), ";") {
+		componentValue, parseError := strconv.Atoi(colorComponent)
+
+		if parseError != nil || componentValue < 0 || componentValue > 255 {
+			return colorCode
+		}
+
+		colorComponents = append(colorComponents, componentValue)
+	}
+
+	if isTrue {
+		if len(colorComponents) != 3 {
+			return colorCode
+		}
+
+		paletteIndex := rgbPaletteIndex(colorComponents[0], colorComponents[1], colorComponents[2])
+
+		if displayedDepth == colorDepthPalette {
+			return Color256(paletteIndex)
+		}
+
+		return basicColor(colorComponents[0], colorComponents[1], colorComponents[2])
+	}
+
+	if len(colorComponents) != 1 {
+		return colorCode
+	}
+
+	paletteIndex := colorComponents[0]
+
+	if paletteIndex < 16 {
+		colorNumber := 30 + paletteIndex%8
+
+		if paletteIndex >= 8 {
+			return "\x1b[" + strconv.Itoa(colorNumber) + ";1m"
+		}
+
+		return "\x1b[" + strconv.Itoa(colorNumber) + "m"
+	}
+
+	redValue, greenValue, blueValue := paletteRGB(paletteIndex)
+
+	return basicColor(redValue, greenValue, blueValue)
+}
+
+// rgbPaletteIndex returns the closest color of the 6x6x6 cube or of the gray ramp of the 256 color palette
+func rgbPaletteIndex(redValue int, greenValue int, blueValue int) uint8 {
+	if redValue == greenValue && greenValue == blueValue {
+		switch {
+		case redValue < 8:
+			return 16
+
+		case redValue > 248:
+			return 231
+
+		default:
+			return uint8(232 + (redValue-8)*24/241)
+		}
+	}
+
+	cubeLevel := func(colorValue int) int {
+		switch {
+		case colorValue < 48:
+			return 0
+
+		case colorValue < 115:
+			return 1
+
+		default:
+			return (colorValue - 35) / 40
+		}
+	}
+
+	return uint8(16 + 36*cubeLevel(redValue) + 6*cubeLevel(greenValue) + cubeLevel(blueValue))
+}
+
+// paletteRGB returns the red, green and blue components of a color of the palette above the basic colors
+func paletteRGB(paletteIndex int) (int, int, int) {
+	if paletteIndex >= 232 {
+		grayValue := 8 + (paletteIndex-232)*10
+
+		return grayValue, grayValue, grayValue
+	}
+
+	cubeIndex := paletteIndex - 16
+	cubeValue := func(cubeLevel int) int {
+		if cubeLevel == 0 {
+			return 0
+		}
+
+		return 55 + cubeLevel*40
+	}
+
+	return cubeValue(cubeIndex / 36), cubeValue(cubeIndex / 6 % 6), cubeValue(cubeIndex % 6)
+}
+
+// basicColor returns the bright basic color closest to the red, green and blue components
+func basicColor(redValue int, greenValue int, blueValue int) string {
+	colorNumber := 30
+
+	if redValue > 127 {
+		colorNumber++
+	}
+
+	if greenValue > 127 {
+		colorNumber += 2
+	}
+
+	if blueValue > 127 {
+		colorNumber += 4
+	}
+
+	return "\x1b[" + strconv.Itoa(colorNumber) + ";1m"
+}
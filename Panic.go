@@ -0,0 +1,56 @@
+// Panic Message Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Panic logs a message to the configured destinations with panic formatting and panics
+func (logInstance *LogInstance) Panic(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessagePanic, jsonContent, messageContent...)
+}
+
+// PanicC logs a message to the terminal with panic formatting and panics
+func (logInstance *LogInstance) PanicC(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, false, true, true, MessagePanic, jsonContent, messageContent...)
+}
+
+// FPanic logs a panic message to the log file and panics
+func (logInstance *LogInstance) FPanic(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, true, false, false, MessagePanic, jsonContent, messageContent...)
+}
+
+// RecoverAndLog recovers from a panic and logs its value along with the stack trace
+// It must be deferred directly, for example defer logInstance.RecoverAndLog(MessageError, false)
+// The message is written with the formatting of messageType to the configured destinations
+// If rePanic is true, the recovered value is raised again after logging
+func (logInstance *LogInstance) RecoverAndLog(messageType string, rePanic bool) {
+	panicValue := recover()
+
+	if panicValue == nil {
+		return
+	}
+
+	printConfigured(logInstance, messageType, nil, "recovered from panic",
+		Any("panic", panicValue), String("stack", string(debug.Stack())))
+
+	if rePanic {
+		panic(panicValue)
+	}
+}
+
+// panicMessage returns the panic value of a panic message without its typed fields
+func panicMessage(messageContent []interface{}) string {
+	_, messageParts := collectFields(nil, nil, messageContent)
+	evaluateLazy(nil, messageParts)
+
+	return fmt.Sprint(messageParts...)
+}
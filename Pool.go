@@ -0,0 +1,44 @@
+// Pooled Line Buffers
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "sync"
+
+// pooledBufferSize is the initial capacity of a line buffer
+const pooledBufferSize int = 512
+
+// maximumPooledSize is the capacity above which a line buffer is left to the garbage collector
+// It keeps a single huge message from pinning its memory in the pool
+const maximumPooledSize int = 64 << 10
+
+// lineBuffers holds the buffers log lines are built in, so steady logging reuses them instead of allocating
+var lineBuffers = sync.Pool{
+	New: func() interface{} {
+		lineBuffer := make([]byte, 0, pooledBufferSize)
+		return &lineBuffer
+	},
+}
+
+// acquireBuffer returns an empty line buffer from the pool
+func acquireBuffer() *[]byte {
+	lineBuffer := lineBuffers.Get().(*[]byte)
+	*lineBuffer = (*lineBuffer)[:0]
+
+	return lineBuffer
+}
+
+// releaseBuffer returns the line buffer to the pool once its content was written
+// Writers must not keep the written content, as io.Writer requires
+func releaseBuffer(lineBuffer *[]byte) {
+	if cap(*lineBuffer) > maximumPooledSize {
+		return
+	}
+
+	lineBuffers.Put(lineBuffer)
+}
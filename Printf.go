@@ -0,0 +1,46 @@
+// Formatted Message Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "fmt"
+
+// Infof formats a message according to the format specifier and logs it with normal formatting
+func (logInstance *LogInstance) Infof(jsonContent map[string]interface{}, messageFormat string, messageArguments ...interface{}) {
+	logInstance.Info(jsonContent, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Warnf formats a message according to the format specifier and logs it with warning formatting
+func (logInstance *LogInstance) Warnf(jsonContent map[string]interface{}, messageFormat string, messageArguments ...interface{}) {
+	logInstance.Warn(jsonContent, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Errorf formats a message according to the format specifier and logs it with error formatting
+func (logInstance *LogInstance) Errorf(jsonContent map[string]interface{}, messageFormat string, messageArguments ...interface{}) {
+	logInstance.Error(jsonContent, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Fatalf formats a message according to the format specifier and logs it with fatal formatting
+func (logInstance *LogInstance) Fatalf(jsonContent map[string]interface{}, messageFormat string, messageArguments ...interface{}) {
+	logInstance.Fatal(jsonContent, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Panicf formats a message according to the format specifier, logs it with panic formatting and panics
+func (logInstance *LogInstance) Panicf(jsonContent map[string]interface{}, messageFormat string, messageArguments ...interface{}) {
+	logInstance.Panic(jsonContent, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Debugf formats a message according to the format specifier and logs it with debug formatting
+func (logInstance *LogInstance) Debugf(jsonContent map[string]interface{}, messageFormat string, messageArguments ...interface{}) {
+	logInstance.Debug(jsonContent, fmt.Sprintf(messageFormat, messageArguments...))
+}
+
+// Tracef formats a message according to the format specifier and logs it with trace formatting
+func (logInstance *LogInstance) Tracef(jsonContent map[string]interface{}, messageFormat string, messageArguments ...interface{}) {
+	logInstance.Trace(jsonContent, fmt.Sprintf(messageFormat, messageArguments...))
+}
@@ -0,0 +1,120 @@
+// RFC 5424 Syslog Line Format
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	rfc5424TimeLayout    string = "2006-01-02T15:04:05.000000Z07:00" // rfc5424TimeLayout is the timestamp layout with the microsecond precision allowed by RFC 5424
+	rfc5424Facility      int    = 1                                  // rfc5424Facility is the user level facility
+	rfc5424NameLength    int    = 32                                 // rfc5424NameLength is the maximum length of a parameter name
+	rfc5424DefaultDataID string = "fields@32473"                     // rfc5424DefaultDataID is the structured data identifier used when none is given
+)
+
+// rfc5424ValueEscaper escapes the parameter values of structured data
+var rfc5424ValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// rfc5424Formatter encodes log messages as RFC 5424 syslog lines
+type rfc5424Formatter struct {
+	hostName         string // hostName is the host name written in the header
+	appName          string // appName is the application name written in the header
+	processID        string // processID is the process identifier written in the header
+	structuredDataID string // structuredDataID is the identifier of the structured data element holding the fields
+}
+
+// NewRFC5424Formatter returns a formatter writing log messages as RFC 5424 syslog lines
+// The fields are written as parameters of the structured data element structuredDataID,
+// which should be a name followed by the private enterprise number of the organization, such as fields@32473
+func NewRFC5424Formatter(appName string, structuredDataID string) Formatter {
+	hostName, _ := os.Hostname()
+
+	if structuredDataID == "" {
+		structuredDataID = rfc5424DefaultDataID
+	}
+
+	return &rfc5424Formatter{
+		hostName:         rfc5424Header(hostName),
+		appName:          rfc5424Header(appName),
+		processID:        strconv.Itoa(os.Getpid()),
+		structuredDataID: rfc5424Name(structuredDataID),
+	}
+}
+
+// Format writes the log message as an RFC 5424 syslog line
+func (logSyslog *rfc5424Formatter) Format(logEntry Entry) ([]byte, error) {
+	var lineBuilder strings.Builder
+
+	messagePriority := rfc5424Facility*8 + syslogSeverity(logEntry.Level)
+
+	lineBuilder.WriteString("<" + strconv.Itoa(messagePriority) + ">1 ")
+	lineBuilder.WriteString(logEntry.Time.Format(rfc5424TimeLayout) + " ")
+	lineBuilder.WriteString(logSyslog.hostName + " " + logSyslog.appName + " " + logSyslog.processID + " ")
+	lineBuilder.WriteString(rfc5424Header(logEntry.LevelName) + " ")
+
+	if len(logEntry.Fields) == 0 {
+		lineBuilder.WriteByte('-')
+	} else {
+		lineBuilder.WriteString("[" + logSyslog.structuredDataID)
+
+		for _, entryField := range logEntry.Fields {
+			lineBuilder.WriteString(" " + rfc5424Name(entryField.Key) + `="` + rfc5424ValueEscaper.Replace(fieldText(entryField)) + `"`)
+		}
+
+		lineBuilder.WriteByte(']')
+	}
+
+	if logEntry.Message != "" {
+		lineBuilder.WriteString(" " + strings.ReplaceAll(logEntry.Message, "\n", " "))
+	}
+
+	return []byte(lineBuilder.String()), nil
+}
+
+// rfc5424Header returns the header value made of printable characters, or the nil value when empty
+func rfc5424Header(headerValue string) string {
+	headerValue = strings.Map(func(headerCharacter rune) rune {
+		if headerCharacter <= ' ' || headerCharacter > '~' {
+			return '_'
+		}
+
+		return headerCharacter
+	}, headerValue)
+
+	if headerValue == "" {
+		return "-"
+	}
+
+	return headerValue
+}
+
+// rfc5424Name returns the structured data name without the characters RFC 5424 forbids
+// Names are cut to 32 characters
+func rfc5424Name(dataName string) string {
+	dataName = strings.Map(func(nameCharacter rune) rune {
+		if nameCharacter <= ' ' || nameCharacter > '~' || nameCharacter == '=' || nameCharacter == ']' || nameCharacter == '"' {
+			return '_'
+		}
+
+		return nameCharacter
+	}, dataName)
+
+	if len(dataName) > rfc5424NameLength {
+		dataName = dataName[:rfc5424NameLength]
+	}
+
+	if dataName == "" {
+		return "_"
+	}
+
+	return dataName
+}
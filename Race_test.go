@@ -0,0 +1,14 @@
+// Race Detector Test Setting
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build race
+
+package GoLog
+
+// raceEnabled reports that the race detector is enabled, which allocates on its own
+const raceEnabled bool = true
@@ -0,0 +1,80 @@
+// Per-Key Rate Limiting
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "time"
+
+// rateLimiter limits the number of messages written under a key within a window
+type rateLimiter struct {
+	messageLimit    int           // messageLimit is the number of messages written per window
+	limitWindow     time.Duration // limitWindow is the length of a rate limiting window
+	windowStart     time.Time     // windowStart is the time the current window began
+	windowCount     int           // windowCount is the number of messages seen within the current window
+	suppressedCount int           // suppressedCount is the number of messages dropped since the last written one
+}
+
+// WithRateLimit returns a child logger writing at most messageLimit messages every limitWindow
+// Child loggers created with the same key share the limit, other logging of the instance is not affected,
+// for example logInstance.WithRateLimit("retry", 5, time.Minute).Warn(nil, "retrying") writes
+// at most 5 retry warnings a minute, the first written message after dropped ones
+// carries the number of dropped messages in the suppressed field
+// Fatal and panic messages are never dropped
+func (logInstance *LogInstance) WithRateLimit(limitKey string, messageLimit int, limitWindow time.Duration) *LogInstance {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	keyLimiter, isKnown := logInstance.rateLimiters[limitKey]
+
+	if !isKnown {
+		keyLimiter = &rateLimiter{}
+		logInstance.rateLimiters[limitKey] = keyLimiter
+	}
+
+	keyLimiter.messageLimit = messageLimit
+	keyLimiter.limitWindow = limitWindow
+
+	childInstance := *logInstance
+	childInstance.rateLimit = keyLimiter
+
+	return &childInstance
+}
+
+// admit reports whether the message is written and adds the suppressed field when needed
+// The log instance lock must be held by the caller
+func (keyLimiter *rateLimiter) admit(messageEntry *logEntry) bool {
+	if keyLimiter == nil || messageEntry.messageType == messageFatal || messageEntry.messageType == MessagePanic {
+		return true
+	}
+
+	return keyLimiter.allow(messageEntry)
+}
+
+// allow reports whether the message is within the limit, whatever its type, and adds the suppressed field when needed
+func (keyLimiter *rateLimiter) allow(messageEntry *logEntry) bool {
+	if messageEntry.entryTime.Sub(keyLimiter.windowStart) >= keyLimiter.limitWindow ||
+		messageEntry.entryTime.Before(keyLimiter.windowStart) {
+		keyLimiter.windowStart = messageEntry.entryTime
+		keyLimiter.windowCount = 0
+	}
+
+	keyLimiter.windowCount++
+
+	if keyLimiter.windowCount > keyLimiter.messageLimit {
+		keyLimiter.suppressedCount++
+		return false
+	}
+
+	if keyLimiter.suppressedCount > 0 {
+		messageEntry.entryFields = append(messageEntry.entryFields[:len(messageEntry.entryFields):len(messageEntry.entryFields)],
+			Int(sampleSuppressedKey, keyLimiter.suppressedCount))
+		keyLimiter.suppressedCount = 0
+	}
+
+	return true
+}
@@ -0,0 +1,267 @@
+// Sensitive Data Redaction
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// redactionMask replaces every redacted value
+const redactionMask string = "[REDACTED]"
+
+// emailPattern matches email addresses
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+
+// cardPattern matches candidate payment card numbers, confirmed with the Luhn check
+var cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+// credentialPattern matches bearer tokens, JSON Web Tokens and well-known API keys
+var credentialPattern = regexp.MustCompile(`(?i:bearer)\s+[A-Za-z0-9\-._~+/]+=*` +
+	`|\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*` +
+	`|\b(?:AKIA|ASIA)[0-9A-Z]{16}\b` +
+	`|\b(?:gh[pousr]_|glpat-|xox[abpr]-)[A-Za-z0-9_-]{10,}`)
+
+// RedactionRule selects values that are replaced with [REDACTED] before a message is encoded
+// Rules are created with RedactFields, RedactPattern and the built-in rules such as RedactEmails
+type RedactionRule struct {
+	fieldKeys    map[string]bool             // fieldKeys holds the lower case keys whose values are always redacted
+	valuePattern *regexp.Regexp              // valuePattern matches the sensitive parts of text values
+	matchFilter  func(matchText string) bool // matchFilter confirms a match of the pattern when set
+}
+
+// WithRedaction applies the redaction rules to the message content and the fields of every message
+// before it is written anywhere, nested maps, slices and structs included
+// Rules are applied before the hooks, so remote hooks such as Sentry and webhooks never see the original values,
+// and again to the fields the hooks added
+func WithRedaction(redactionRules ...RedactionRule) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.redactionRules = append(logInstance.redactionRules[:len(logInstance.redactionRules):len(logInstance.redactionRules)],
+			redactionRules...)
+	}
+}
+
+// RedactFields redacts the whole value of the fields and nested map keys named in fieldKeys, ignoring case,
+// for example RedactFields("password", "authorization")
+func RedactFields(fieldKeys ...string) RedactionRule {
+	redactedKeys := make(map[string]bool, len(fieldKeys))
+
+	for _, fieldKey := range fieldKeys {
+		redactedKeys[strings.ToLower(fieldKey)] = true
+	}
+
+	return RedactionRule{fieldKeys: redactedKeys}
+}
+
+// RedactPattern redacts every part of the message content and of text values matching the pattern
+func RedactPattern(valuePattern *regexp.Regexp) RedactionRule {
+	return RedactionRule{valuePattern: valuePattern}
+}
+
+// RedactEmails redacts email addresses
+func RedactEmails() RedactionRule {
+	return RedactPattern(emailPattern)
+}
+
+// RedactCreditCards redacts payment card numbers of 13 to 19 digits passing the Luhn check,
+// with or without space and dash separators
+func RedactCreditCards() RedactionRule {
+	return RedactionRule{valuePattern: cardPattern, matchFilter: luhnValid}
+}
+
+// RedactTokens redacts bearer tokens, JSON Web Tokens and well-known API keys of AWS, GitHub, GitLab and Slack
+func RedactTokens() RedactionRule {
+	return RedactPattern(credentialPattern)
+}
+
+// redactEntry applies the redaction rules to the message content and the fields of the log message entry
+func redactEntry(logInstance *LogInstance, messageEntry *logEntry) {
+	if len(logInstance.redactionRules) == 0 {
+		return
+	}
+
+	if len(messageEntry.messageParts) > 0 {
+		messageText := fmt.Sprint(messageEntry.messageParts...)
+
+		if redactedText := redactText(logInstance.redactionRules, messageText); redactedText != messageText {
+			messageEntry.messageParts = []interface{}{redactedText}
+		}
+	}
+
+	if len(messageEntry.entryFields) == 0 {
+		return
+	}
+
+	redactedFields := make([]Field, len(messageEntry.entryFields))
+
+	for fieldIndex, entryField := range messageEntry.entryFields {
+		redactedFields[fieldIndex] = redactField(logInstance.redactionRules, entryField)
+	}
+
+	messageEntry.entryFields = redactedFields
+}
+
+// redactField returns the field with its sensitive value redacted
+func redactField(redactionRules []RedactionRule, entryField Field) Field {
+	if redactedKey(redactionRules, entryField.Key) {
+		return String(entryField.Key, redactionMask)
+	}
+
+	switch entryField.valueType {
+	case fieldString:
+		return String(entryField.Key, redactText(redactionRules, entryField.stringValue))
+
+	case fieldError:
+		if entryField.interfaceValue == nil {
+			return entryField
+		}
+
+		errorText := fieldText(entryField)
+
+		if redactedText := redactText(redactionRules, errorText); redactedText != errorText {
+			return String(entryField.Key, redactedText)
+		}
+
+	case fieldAny:
+		return Any(entryField.Key, redactValue(redactionRules, normalizeValue(entryField.interfaceValue, 0), 0))
+	}
+
+	return entryField
+}
+
+// redactValue returns the normalized value with its sensitive parts redacted
+// Structs and values with their own encoding are redacted in their JSON or text form
+func redactValue(redactionRules []RedactionRule, fieldValue interface{}, nestingDepth int) interface{} {
+	if nestingDepth > maximumNestingDepth {
+		return redactText(redactionRules, fmt.Sprint(fieldValue))
+	}
+
+	switch typedValue := fieldValue.(type) {
+	case nil, bool, []byte:
+		return fieldValue
+
+	case string:
+		return redactText(redactionRules, typedValue)
+
+	case map[string]interface{}:
+		redactedMap := make(map[string]interface{}, len(typedValue))
+
+		for mapKey, mapValue := range typedValue {
+			if redactedKey(redactionRules, mapKey) {
+				redactedMap[mapKey] = redactionMask
+			} else {
+				redactedMap[mapKey] = redactValue(redactionRules, mapValue, nestingDepth+1)
+			}
+		}
+
+		return redactedMap
+
+	case []interface{}:
+		redactedSlice := make([]interface{}, len(typedValue))
+
+		for sliceIndex, sliceValue := range typedValue {
+			redactedSlice[sliceIndex] = redactValue(redactionRules, sliceValue, nestingDepth+1)
+		}
+
+		return redactedSlice
+
+	case json.Marshaler:
+		var decodedValue interface{}
+
+		if encodedValue, encodeError := typedValue.MarshalJSON(); encodeError == nil && json.Unmarshal(encodedValue, &decodedValue) == nil {
+			return redactValue(redactionRules, decodedValue, nestingDepth+1)
+		}
+
+	case encoding.TextMarshaler:
+		if encodedValue, encodeError := typedValue.MarshalText(); encodeError == nil {
+			return redactText(redactionRules, string(encodedValue))
+		}
+	}
+
+	switch reflect.ValueOf(fieldValue).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return fieldValue
+
+	case reflect.Struct:
+		var decodedValue interface{}
+
+		if encodedValue, encodeError := json.Marshal(fieldValue); encodeError == nil && json.Unmarshal(encodedValue, &decodedValue) == nil {
+			return redactValue(redactionRules, decodedValue, nestingDepth+1)
+		}
+	}
+
+	return redactText(redactionRules, fmt.Sprint(fieldValue))
+}
+
+// redactedKey reports whether a rule redacts the whole value of the key
+func redactedKey(redactionRules []RedactionRule, fieldKey string) bool {
+	lowerKey := strings.ToLower(fieldKey)
+
+	for _, redactionRule := range redactionRules {
+		if redactionRule.fieldKeys[lowerKey] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactText returns the text with every part matching a rule pattern replaced
+func redactText(redactionRules []RedactionRule, valueText string) string {
+	for _, redactionRule := range redactionRules {
+		if redactionRule.valuePattern == nil {
+			continue
+		}
+
+		valueText = redactionRule.valuePattern.ReplaceAllStringFunc(valueText, func(matchText string) string {
+			if redactionRule.matchFilter != nil && !redactionRule.matchFilter(matchText) {
+				return matchText
+			}
+
+			return redactionMask
+		})
+	}
+
+	return valueText
+}
+
+// luhnValid reports whether the digits of the text pass the Luhn checksum of payment card numbers
+func luhnValid(cardText string) bool {
+	checksumValue := 0
+	digitCount := 0
+
+	for characterIndex := len(cardText) - 1; characterIndex >= 0; characterIndex-- {
+		cardCharacter := cardText[characterIndex]
+
+		if cardCharacter < '0' || cardCharacter > '9' {
+			continue
+		}
+
+		digitValue := int(cardCharacter - '0')
+
+		if digitCount%2 == 1 {
+			digitValue *= 2
+
+			if digitValue > 9 {
+				digitValue -= 9
+			}
+		}
+
+		checksumValue += digitValue
+		digitCount++
+	}
+
+	return digitCount >= 13 && checksumValue%10 == 0
+}
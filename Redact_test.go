@@ -0,0 +1,45 @@
+// Sensitive Data Redaction Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRedactionBeforeHooks(t *testing.T) {
+	var hookEntry Entry
+	var logOutput bytes.Buffer
+
+	logInstance := InitializeWriter(&logOutput, WithTerminal(false), WithRedaction(RedactEmails()))
+	logInstance.AddHook(HookFunc(func(firedEntry *Entry) bool {
+		hookEntry = *firedEntry
+		firedEntry.Fields = append(firedEntry.Fields, String("reporter", "bob@example.com"))
+
+		return true
+	}))
+
+	logInstance.Info(nil, "contact alice@example.com", String("email", "carol@example.com"))
+	logInstance.Close()
+
+	if strings.Contains(hookEntry.Message, "alice@example.com") {
+		t.Errorf("hook received the message %q", hookEntry.Message)
+	}
+
+	for _, hookField := range hookEntry.Fields {
+		if strings.Contains(fieldText(hookField), "carol@example.com") {
+			t.Errorf("hook received the field %s", hookField.Key)
+		}
+	}
+
+	if writtenLine := logOutput.String(); strings.Contains(writtenLine, "@example.com") {
+		t.Errorf("written line %q contains an email address", writtenLine)
+	}
+}
@@ -0,0 +1,279 @@
+// Configuration Hot Reload
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// configState remembers what the applied configuration added to a log instance, so the next one can replace it
+type configState struct {
+	logConfig       Config        // logConfig is the applied configuration
+	logDestinations []Destination // logDestinations are the destinations opened for the configuration
+}
+
+// Reconfigure applies the configuration to the running log instance
+// The level, formats, terminal, color, time, caller and stack settings, the fields, the destinations of the previous
+// configuration and the file output are replaced together under the lock of the log instance, settings left empty
+// return to their defaults and settings made in code that a configuration cannot describe, such as hooks, stay
+// With asynchronous logging, the queued messages are written with the previous settings first, so none is dropped
+// The log file is reopened only when the file or its rotation and buffer settings change, in append mode for the same file
+// Child loggers created before the call write to the new file output and destinations but keep the other previous settings
+func (logInstance *LogInstance) Reconfigure(logConfig Config) error {
+	logOptions, optionError := logConfig.options()
+
+	if optionError != nil {
+		return optionError
+	}
+
+	logDestinations, destinationError := logConfig.openDestinations()
+
+	if destinationError != nil {
+		return destinationError
+	}
+
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	previousState := logInstance.logOutput.logConfig
+	configuredInstance := *logInstance
+	configuredInstance.resetConfigured()
+
+	// The configured copy writes to outputs of its own until the configuration is applied
+
+	configuredOutput := *logInstance.logOutput
+	configuredInstance.logOutput = &configuredOutput
+
+	if previousState != nil {
+		configuredInstance.boundFields = withoutKeys(logInstance.boundFields, previousState.logConfig.fieldKeys())
+		configuredInstance.logOutput.logDestinations = withoutDestinations(logInstance.logOutput.logDestinations, previousState.logDestinations)
+	}
+
+	configuredInstance.logOutput.logDestinations = append(append([]Destination{}, configuredInstance.logOutput.logDestinations...),
+		logDestinations...)
+
+	for _, logOption := range logOptions {
+		logOption(&configuredInstance)
+	}
+
+	configuredInstance.logOutput.logConfig = &configState{logConfig: logConfig, logDestinations: logDestinations}
+	reopenOutput := logConfig.File != "" && (logConfig.File != logInstance.logOutput.logPath ||
+		previousState != nil && !previousState.logConfig.sameFileOutput(logConfig))
+
+	if reopenOutput {
+		configuredInstance.appendOutput = configuredInstance.appendOutput || logConfig.File == logInstance.logOutput.logPath
+		configuredInstance.logOutput.logPath = logConfig.File
+
+		if openError := configuredInstance.openFileOutput(); openError != nil {
+			closeConfigDestinations(logDestinations)
+			return openError
+		}
+	}
+
+	// Write the queued messages with the previous settings
+
+	logInstance.runTaskWait(func() {})
+
+	previousInstance := *logInstance
+	previousOutput := *logInstance.logOutput
+	logInstance.takeConfigured(&configuredInstance)
+
+	var reconfigureError error
+
+	if reopenOutput {
+		reconfigureError = flushOutput(previousOutput.logWriter)
+
+		if logCloser, isCloser := previousOutput.logWriter.(io.Closer); isCloser && !isStandardStream(previousOutput.logWriter) {
+			if closeError := logCloser.Close(); reconfigureError == nil {
+				reconfigureError = closeError
+			}
+		}
+	}
+
+	if previousState != nil {
+		closeConfigDestinations(previousState.logDestinations)
+	}
+
+	if logInstance.asyncSize != previousInstance.asyncSize || logInstance.overflowPolicy != previousInstance.overflowPolicy {
+		if previousOutput.logQueue != nil {
+			previousOutput.logQueue.stop()
+		}
+
+		logInstance.logOutput.logQueue = nil
+		logInstance.startQueue()
+	}
+
+	return reconfigureError
+}
+
+// WatchConfigFile reconfigures the log instance every time the configuration file at configPath changes
+// The file is checked every pollInterval, a configuration that cannot be read or applied is reported
+// as a diagnostic and the previous settings stay in effect
+// It returns a function that stops watching
+func (logInstance *LogInstance) WatchConfigFile(configPath string, pollInterval time.Duration) func() {
+	stopChannel := make(chan struct{})
+	lastInformation, _ := os.Stat(configPath)
+
+	go func() {
+		pollTicker := time.NewTicker(pollInterval)
+		defer pollTicker.Stop()
+
+		for {
+			select {
+			case <-pollTicker.C:
+				fileInformation, statError := os.Stat(configPath)
+
+				if statError != nil || lastInformation != nil && fileInformation.ModTime().Equal(lastInformation.ModTime()) &&
+					fileInformation.Size() == lastInformation.Size() {
+					continue
+				}
+
+				lastInformation = fileInformation
+				logConfig, loadError := LoadConfig(configPath)
+
+				if loadError == nil {
+					loadError = logInstance.Reconfigure(logConfig)
+				}
+
+				if loadError != nil {
+					logInstance.diagnose(DiagnosticReload, "unable to reload the log configuration", loadError)
+				}
+
+			case <-stopChannel:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stopChannel)
+	}
+}
+
+// takeConfigured moves the settings a configuration describes, its fields, destinations and file output
+// from the configured copy into the log instance
+func (logInstance *LogInstance) takeConfigured(configuredInstance *LogInstance) {
+	logInstance.fileOutput = configuredInstance.fileOutput
+	logInstance.appendOutput = configuredInstance.appendOutput
+	logInstance.terminalOutput = configuredInstance.terminalOutput
+	logInstance.logLevel.Store(configuredInstance.logLevel.Load())
+	logInstance.stderrLevel = configuredInstance.stderrLevel
+	logInstance.outputFormat = configuredInstance.outputFormat
+	logInstance.terminalFormatter = configuredInstance.terminalFormatter
+	logInstance.colorOutput = configuredInstance.colorOutput
+	logInstance.colorMode = configuredInstance.colorMode
+	logInstance.timeFormat = configuredInstance.timeFormat
+	logInstance.utcTime = configuredInstance.utcTime
+	logInstance.captureCaller = configuredInstance.captureCaller
+	logInstance.captureStack = configuredInstance.captureStack
+	logInstance.stackLevel = configuredInstance.stackLevel
+	logInstance.logRotation = configuredInstance.logRotation
+	logInstance.bufferSize = configuredInstance.bufferSize
+	logInstance.flushInterval = configuredInstance.flushInterval
+	logInstance.asyncSize = configuredInstance.asyncSize
+	logInstance.overflowPolicy = configuredInstance.overflowPolicy
+
+	logInstance.boundFields = configuredInstance.boundFields
+	logInstance.logOutput.logDestinations = configuredInstance.logOutput.logDestinations
+	logInstance.logOutput.logConfig = configuredInstance.logOutput.logConfig
+	logInstance.logOutput.logPath = configuredInstance.logOutput.logPath
+	logInstance.logOutput.logWriter = configuredInstance.logOutput.logWriter
+	logInstance.LogDestination = configuredInstance.LogDestination
+}
+
+// resetConfigured returns the settings a configuration describes to their defaults
+func (logInstance *LogInstance) resetConfigured() {
+	defaultInstance := newInstance()
+
+	logInstance.fileOutput = defaultInstance.fileOutput
+	logInstance.appendOutput = defaultInstance.appendOutput
+	logInstance.terminalOutput = defaultInstance.terminalOutput
+	logInstance.logLevel = defaultInstance.logLevel
+	logInstance.stderrLevel = defaultInstance.stderrLevel
+	logInstance.outputFormat = defaultInstance.outputFormat
+	logInstance.terminalFormatter = defaultInstance.terminalFormatter
+	logInstance.colorOutput = defaultInstance.colorOutput
+	logInstance.colorMode = defaultInstance.colorMode
+	logInstance.timeFormat = defaultInstance.timeFormat
+	logInstance.utcTime = defaultInstance.utcTime
+	logInstance.captureCaller = defaultInstance.captureCaller
+	logInstance.captureStack = defaultInstance.captureStack
+	logInstance.stackLevel = defaultInstance.stackLevel
+	logInstance.logRotation = defaultInstance.logRotation
+	logInstance.bufferSize = defaultInstance.bufferSize
+	logInstance.flushInterval = defaultInstance.flushInterval
+	logInstance.asyncSize = defaultInstance.asyncSize
+	logInstance.overflowPolicy = defaultInstance.overflowPolicy
+}
+
+// fieldKeys returns the keys of the fields the configuration adds to every message
+func (logConfig Config) fieldKeys() []string {
+	var fieldKeys []string
+
+	if logConfig.Service != "" {
+		fieldKeys = append(fieldKeys, "service")
+	}
+
+	if logConfig.Version != "" {
+		fieldKeys = append(fieldKeys, "version")
+	}
+
+	for fieldKey := range logConfig.Fields {
+		fieldKeys = append(fieldKeys, fieldKey)
+	}
+
+	return fieldKeys
+}
+
+// sameFileOutput reports whether both configurations open the file output in the same way
+func (logConfig Config) sameFileOutput(otherConfig Config) bool {
+	return logConfig.File == otherConfig.File && logConfig.Rotation == otherConfig.Rotation &&
+		logConfig.BufferSize == otherConfig.BufferSize && logConfig.FlushInterval == otherConfig.FlushInterval
+}
+
+// withoutKeys returns the fields whose keys are not listed, nil when none is kept
+// A message without fields is written without the field brackets only when its fields are nil
+func withoutKeys(logFields []Field, fieldKeys []string) []Field {
+	var keptFields []Field
+
+	for _, logField := range logFields {
+		isListed := false
+
+		for _, fieldKey := range fieldKeys {
+			isListed = isListed || logField.Key == fieldKey
+		}
+
+		if !isListed {
+			keptFields = append(keptFields, logField)
+		}
+	}
+
+	return keptFields
+}
+
+// withoutDestinations returns the destinations that are not listed
+func withoutDestinations(logDestinations []Destination, listedDestinations []Destination) []Destination {
+	keptDestinations := make([]Destination, 0, len(logDestinations))
+
+	for _, logDestination := range logDestinations {
+		isListed := false
+
+		for _, listedDestination := range listedDestinations {
+			isListed = isListed || logDestination == listedDestination
+		}
+
+		if !isListed {
+			keptDestinations = append(keptDestinations, logDestination)
+		}
+	}
+
+	return keptDestinations
+}
@@ -0,0 +1,63 @@
+// Reload Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReconfigureReachesChildLoggers(t *testing.T) {
+	previousPath := filepath.Join(t.TempDir(), "a.log")
+	currentPath := filepath.Join(t.TempDir(), "b.log")
+
+	logInstance, initializeError := InitializeE(previousPath, WithTerminal(false))
+
+	if initializeError != nil {
+		t.Fatalf("initializing the log instance failed: %v", initializeError)
+	}
+
+	namedInstance := logInstance.Named("database")
+	boundInstance := logInstance.With(nil, String("request", "r-01"))
+
+	if reconfigureError := logInstance.Reconfigure(Config{File: currentPath}); reconfigureError != nil {
+		t.Fatalf("reconfiguring the log instance failed: %v", reconfigureError)
+	}
+
+	namedInstance.FLog(nil, "named message")
+	boundInstance.FLog(nil, "bound message")
+
+	if closeError := logInstance.Close(); closeError != nil {
+		t.Fatalf("closing the log instance failed: %v", closeError)
+	}
+
+	boundInstance.FLog(nil, "message after close")
+
+	currentContent, readError := os.ReadFile(currentPath)
+
+	if readError != nil {
+		t.Fatalf("reading the reconfigured log file failed: %v", readError)
+	}
+
+	for _, expectedMessage := range []string{"named message", "bound message"} {
+		if !strings.Contains(string(currentContent), expectedMessage) {
+			t.Errorf("reconfigured log file %q misses %q", currentContent, expectedMessage)
+		}
+	}
+
+	if strings.Contains(string(currentContent), "message after close") {
+		t.Errorf("reconfigured log file %q holds a message logged after Close", currentContent)
+	}
+
+	if previousContent, _ := os.ReadFile(previousPath); len(previousContent) > 0 {
+		t.Errorf("previous log file holds %q, want no message", previousContent)
+	}
+}
@@ -0,0 +1,102 @@
+// Log File Reopening
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+)
+
+// Reopen closes the log file and opens the log file path again in append mode
+// External tools such as logrotate can move the log file and ask the process to reopen it
+// If the log instance was initialized with a writer, it returns an error
+func (logInstance *LogInstance) Reopen() error {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	logRotate, isReopener := logInstance.logOutput.logWriter.(fileReopener)
+
+	if !isReopener {
+		return errors.New("log instance has no file path to reopen")
+	}
+
+	var reopenError error
+
+	logInstance.runTaskWait(func() {
+		if reopenError = logRotate.reopen(); reopenError == nil {
+			logInstance.LogDestination = logRotate.currentFile()
+		}
+	})
+
+	return reopenError
+}
+
+// fileReopener is implemented by writers that can reopen their log file path
+type fileReopener interface {
+	fileProvider
+	reopen() error
+}
+
+// ReopenOnSignal reopens the log file every time the process receives SIGHUP
+// It returns a function that stops handling the signal, outside Unix systems the signal does not exist and nothing is handled
+func (logInstance *LogInstance) ReopenOnSignal() func() {
+	if reopenSignal == nil {
+		return func() {}
+	}
+
+	signalChannel := make(chan os.Signal, 1)
+	stopChannel := make(chan struct{})
+
+	signal.Notify(signalChannel, reopenSignal)
+
+	go func() {
+		for {
+			select {
+			case <-signalChannel:
+				if reopenError := logInstance.Reopen(); reopenError != nil {
+					logInstance.diagnose(DiagnosticReopen, "unable to reopen the log file", reopenError)
+				}
+
+			case <-stopChannel:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signalChannel)
+		close(stopChannel)
+	}
+}
+
+// reopen closes the active log file and opens the log file path again in append mode
+func (logRotate *rotateWriter) reopen() error {
+	logRotate.backgroundGroup.Wait()
+
+	fileDescriptor, openError := openFile(logRotate.filePath, true)
+
+	if openError != nil {
+		return openError
+	}
+
+	fileInformation, statError := fileDescriptor.Stat()
+
+	if statError != nil {
+		fileDescriptor.Close()
+		return statError
+	}
+
+	closeError := logRotate.fileDescriptor.Close()
+
+	logRotate.fileDescriptor = fileDescriptor
+	logRotate.fileSize = fileInformation.Size()
+
+	return closeError
+}
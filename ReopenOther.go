@@ -0,0 +1,16 @@
+// Reopen Signal on Other Systems
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build !unix
+
+package GoLog
+
+import "os"
+
+// reopenSignal is nil because SIGHUP is never delivered outside Unix systems
+var reopenSignal os.Signal
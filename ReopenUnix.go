@@ -0,0 +1,19 @@
+// Reopen Signal on Unix Systems
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build unix
+
+package GoLog
+
+import (
+	"os"
+	"syscall"
+)
+
+// reopenSignal makes the log instance reopen its log file
+var reopenSignal os.Signal = syscall.SIGHUP
@@ -0,0 +1,122 @@
+// Duplicate Message Suppression
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"strconv"
+	"time"
+)
+
+// repeatCountKey is the key of the field holding the number of collapsed repetitions
+const repeatCountKey string = "repeated"
+
+// repeatFilter collapses consecutive identical messages into a repetition summary
+// Messages are identical when they share the message type, the caller, the message text and the fields
+type repeatFilter struct {
+	repeatTimeout time.Duration // repeatTimeout is the delay after which pending repetitions are reported
+	repeatTimer   *time.Timer   // repeatTimer reports the pending repetitions once the timeout elapses
+
+	lastKey         string          // lastKey identifies the last written message
+	lastType        string          // lastType is the message type of the last written message
+	lastLevel       levelDefinition // lastLevel is the level of the last written message
+	lastInstance    *LogInstance    // lastInstance is the log instance that wrote the last message
+	repeatCount     int             // repeatCount is the number of repetitions not reported yet
+	needFile        bool            // needFile selects file output for the repetition summary
+	needTerminal    bool            // needTerminal selects terminal output for the repetition summary
+	needColorOutput bool            // needColorOutput selects colored terminal output for the repetition summary
+}
+
+// WithRepeatSuppression collapses consecutive identical messages like classic syslog
+// The first message is written and its repetitions are counted, a message reading
+// "last message repeated N times" with the count in the repeated field is written
+// once a different message arrives, once repeatTimeout elapses or when the log instance is closed
+// Fatal and panic messages are never collapsed
+func WithRepeatSuppression(repeatTimeout time.Duration) Option {
+	return func(logInstance *LogInstance) {
+		if repeatTimeout <= 0 {
+			logInstance.logRepeat = nil
+			return
+		}
+
+		logInstance.logRepeat = &repeatFilter{repeatTimeout: repeatTimeout}
+	}
+}
+
+// suppress reports whether the message repeats the last one and is only counted
+// A different message first writes the summary of the pending repetitions
+// The log instance lock must be held by the caller
+func (logRepeat *repeatFilter) suppress(logInstance *LogInstance, needFileOutput bool,
+	needTerminalOutput bool, needTerminalColoredOutput bool, messageEntry logEntry) bool {
+	if logRepeat == nil {
+		return false
+	}
+
+	entryKey := messageEntry.messageType + entryText(messageEntry)
+
+	if entryKey == logRepeat.lastKey && messageEntry.messageType != messageFatal && messageEntry.messageType != MessagePanic {
+		logRepeat.repeatCount++
+
+		if logRepeat.repeatTimer == nil {
+			logRepeat.repeatTimer = time.AfterFunc(logRepeat.repeatTimeout, logRepeat.expire)
+		}
+
+		return true
+	}
+
+	logRepeat.report(messageEntry.entryTime)
+
+	logRepeat.lastKey = entryKey
+	logRepeat.lastType = messageEntry.messageType
+	logRepeat.lastLevel = messageEntry.messageLevel
+	logRepeat.lastInstance = logInstance
+	logRepeat.needFile = needFileOutput
+	logRepeat.needTerminal = needTerminalOutput
+	logRepeat.needColorOutput = needTerminalColoredOutput
+
+	return false
+}
+
+// expire reports the pending repetitions once the timeout elapses
+// Later repetitions of the same message keep being counted
+func (logRepeat *repeatFilter) expire() {
+	logLock := logRepeat.lastInstance.logLock
+
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	logRepeat.report(logRepeat.lastInstance.logClock())
+}
+
+// report writes the summary of the pending repetitions at the time and stops the timeout
+// The log instance lock must be held by the caller
+func (logRepeat *repeatFilter) report(reportTime time.Time) {
+	if logRepeat.repeatTimer != nil {
+		logRepeat.repeatTimer.Stop()
+		logRepeat.repeatTimer = nil
+	}
+
+	if logRepeat.repeatCount == 0 {
+		return
+	}
+
+	logInstance := logRepeat.lastInstance
+	repeatCount := logRepeat.repeatCount
+	logRepeat.repeatCount = 0
+
+	summaryEntry := logEntry{
+		entryTime:    reportTime,
+		messageType:  logRepeat.lastType,
+		messageLevel: logRepeat.lastLevel,
+		entryFields:  []Field{Int(repeatCountKey, repeatCount)},
+		messageParts: []interface{}{"last message repeated " + strconv.Itoa(repeatCount) + " times"},
+	}
+
+	writeEntries(logInstance, logRepeat.needFile, logRepeat.needTerminal,
+		logRepeat.needColorOutput, []logEntry{summaryEntry})
+}
@@ -0,0 +1,156 @@
+// Rotated File Retention
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithMaxBackups keeps at most maxBackups rotated log files and deletes the oldest ones
+func WithMaxBackups(maxBackups int) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logRotation.maxBackups = maxBackups
+	}
+}
+
+// WithMaxAge deletes rotated log files that were last written more than maxAge ago
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logRotation.maxAge = maxAge
+	}
+}
+
+// rotatedFile holds the path and modification time of a rotated log file
+type rotatedFile struct {
+	filePath     string    // filePath is the path of the rotated log file
+	modifiedTime time.Time // modifiedTime is the time the rotated log file was last written
+}
+
+// pruneBackups deletes the rotated log files exceeding the retention limits
+// The newest files are kept first, based on their modification time
+func (logRotate *rotateWriter) pruneBackups(movedPath string) error {
+	rotatedFiles, listError := logRotate.listBackups(movedPath)
+
+	if listError != nil {
+		return listError
+	}
+
+	sort.Slice(rotatedFiles, func(firstIndex int, secondIndex int) bool {
+		return rotatedFiles[firstIndex].modifiedTime.After(rotatedFiles[secondIndex].modifiedTime)
+	})
+
+	var pruneError error
+
+	ageLimit := logRotate.logRotation.logClock().Add(-logRotate.logRotation.maxAge)
+
+	for fileIndex, backupFile := range rotatedFiles {
+		exceedsCount := logRotate.logRotation.maxBackups > 0 && fileIndex >= logRotate.logRotation.maxBackups
+		exceedsAge := logRotate.logRotation.maxAge > 0 && backupFile.modifiedTime.Before(ageLimit)
+
+		if !exceedsCount && !exceedsAge {
+			continue
+		}
+
+		if removeError := os.Remove(backupFile.filePath); removeError != nil && pruneError == nil {
+			pruneError = removeError
+		}
+	}
+
+	return pruneError
+}
+
+// listBackups returns every rotated log file found next to the log file and the last rotated file
+func (logRotate *rotateWriter) listBackups(movedPath string) ([]rotatedFile, error) {
+	var rotatedFiles []rotatedFile
+
+	searchDirectories := []string{filepath.Dir(logRotate.filePath)}
+
+	if movedDirectory := filepath.Dir(movedPath); movedDirectory != searchDirectories[0] {
+		searchDirectories = append(searchDirectories, movedDirectory)
+	}
+
+	for _, searchDirectory := range searchDirectories {
+		directoryEntries, readError := os.ReadDir(searchDirectory)
+
+		if readError != nil {
+			return nil, readError
+		}
+
+		for _, directoryEntry := range directoryEntries {
+			if directoryEntry.IsDir() || !logRotate.isBackup(directoryEntry.Name()) {
+				continue
+			}
+
+			fileInformation, infoError := directoryEntry.Info()
+
+			if infoError != nil {
+				continue
+			}
+
+			rotatedFiles = append(rotatedFiles, rotatedFile{
+				filePath:     filepath.Join(searchDirectory, directoryEntry.Name()),
+				modifiedTime: fileInformation.ModTime(),
+			})
+		}
+	}
+
+	return rotatedFiles, nil
+}
+
+// isBackup reports whether the file name belongs to a rotated log file
+// Compressed and numbered suffixes are ignored while matching the rotated name
+func (logRotate *rotateWriter) isBackup(fileName string) bool {
+	fileName = strings.TrimSuffix(fileName, compressedExtension)
+	logName := filepath.Base(logRotate.filePath)
+
+	if numberedSuffix, isNumbered := strings.CutPrefix(fileName, logName+"."); isNumbered {
+		if _, parseError := strconv.Atoi(numberedSuffix); parseError == nil {
+			return true
+		}
+	}
+
+	if logRotate.logRotation.rotateInterval == RotateNever {
+		return false
+	}
+
+	if suffixIndex := strings.LastIndex(fileName, "."); suffixIndex >= 0 {
+		if _, parseError := strconv.Atoi(fileName[suffixIndex+1:]); parseError == nil {
+			fileName = fileName[:suffixIndex]
+		}
+	}
+
+	if logRotate.logRotation.filePattern != "" {
+		_, parseError := time.Parse(filepath.Base(logRotate.logRotation.filePattern), fileName)
+
+		return parseError == nil
+	}
+
+	fileExtension := filepath.Ext(logName)
+	periodText, hasPrefix := strings.CutPrefix(fileName, strings.TrimSuffix(logName, fileExtension)+"-")
+	periodText, hasSuffix := strings.CutSuffix(periodText, fileExtension)
+
+	if !hasPrefix || !hasSuffix {
+		return false
+	}
+
+	periodLayout := "2006-01-02"
+
+	if logRotate.logRotation.rotateInterval == RotateHourly {
+		periodLayout = "2006-01-02-15"
+	}
+
+	_, parseError := time.Parse(periodLayout, periodText)
+
+	return parseError == nil
+}
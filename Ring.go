@@ -0,0 +1,84 @@
+// In-Memory Ring Buffer
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "fmt"
+
+// ringBuffer keeps the latest log messages dropped by the level filter
+// They are written ahead of the next severe message to give it context
+type ringBuffer struct {
+	ringEntries  []logEntry // ringEntries holds the kept messages in a circular order
+	ringStart    int        // ringStart is the index of the oldest kept message
+	ringLength   int        // ringLength is the number of kept messages
+	captureLevel Level      // captureLevel is the minimum severity a dropped message needs to be kept
+	triggerLevel Level      // triggerLevel is the minimum severity of a message writing out the kept messages
+}
+
+// WithRingBuffer keeps the latest ringCapacity messages at or above captureLevel that the level filter drops
+// Once a message at or above triggerLevel is written, the kept messages are written first and forgotten,
+// for example WithLevel(LevelNormal) with WithRingBuffer(100, LevelDebug, LevelError) writes
+// the last 100 debug messages along with every error
+func WithRingBuffer(ringCapacity int, captureLevel Level, triggerLevel Level) Option {
+	return func(logInstance *LogInstance) {
+		if ringCapacity <= 0 {
+			logInstance.logRing = nil
+			return
+		}
+
+		logInstance.logRing = &ringBuffer{
+			ringEntries:  make([]logEntry, ringCapacity),
+			captureLevel: captureLevel,
+			triggerLevel: triggerLevel,
+		}
+	}
+}
+
+// levelEnabled reports whether a message of the severity is written or kept in the ring buffer
+// The log instance lock must be held by the caller
+func (logInstance *LogInstance) levelEnabled(levelSeverity Level) bool {
+	if levelSeverity >= logInstance.minimumLevel() {
+		return true
+	}
+
+	return logInstance.logRing != nil && levelSeverity >= logInstance.logRing.captureLevel
+}
+
+// add keeps the message, replacing the oldest one once the ring buffer is full
+// The message content is rendered right away so later changes to its values are not seen
+func (logRing *ringBuffer) add(messageEntry logEntry) {
+	if len(messageEntry.messageParts) > 0 {
+		messageEntry.messageParts = []interface{}{fmt.Sprint(messageEntry.messageParts...)}
+	}
+
+	ringCapacity := len(logRing.ringEntries)
+	logRing.ringEntries[(logRing.ringStart+logRing.ringLength)%ringCapacity] = messageEntry
+
+	if logRing.ringLength < ringCapacity {
+		logRing.ringLength++
+	} else {
+		logRing.ringStart = (logRing.ringStart + 1) % ringCapacity
+	}
+}
+
+// drain returns the kept messages from the oldest to the newest and empties the ring buffer
+func (logRing *ringBuffer) drain() []logEntry {
+	ringCapacity := len(logRing.ringEntries)
+	keptEntries := make([]logEntry, 0, logRing.ringLength)
+
+	for ringIndex := 0; ringIndex < logRing.ringLength; ringIndex++ {
+		ringPosition := (logRing.ringStart + ringIndex) % ringCapacity
+		keptEntries = append(keptEntries, logRing.ringEntries[ringPosition])
+		logRing.ringEntries[ringPosition] = logEntry{}
+	}
+
+	logRing.ringStart = 0
+	logRing.ringLength = 0
+
+	return keptEntries
+}
@@ -0,0 +1,367 @@
+// Log File Rotation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationInterval represents the schedule of time based log file rotation
+type RotationInterval int
+
+const (
+	RotateNever  RotationInterval = iota // RotateNever disables time based rotation
+	RotateHourly                         // RotateHourly rotates the log file at the start of every hour
+	RotateDaily                          // RotateDaily rotates the log file at midnight
+)
+
+// rotationSettings holds the conditions that trigger a log file rotation
+type rotationSettings struct {
+	maxSize        int64            // maxSize is the size in bytes after which the log file is rotated
+	rotateInterval RotationInterval // rotateInterval is the schedule of time based rotation
+	filePattern    string           // filePattern is the time layout used to name time rotated files
+	compressFiles  bool             // compressFiles selects gzip compression of rotated files
+	maxBackups     int              // maxBackups is the number of rotated files to keep
+	maxAge         time.Duration    // maxAge is the age after which rotated files are deleted
+	logClock       func() time.Time // logClock returns the current time for rotation and retention
+
+	reportDiagnostic func(diagnosticComponent string, diagnosticMessage string, diagnosticError error) // reportDiagnostic reports the failures of background tasks
+}
+
+// isEnabled reports whether any rotation condition is configured
+func (logRotation rotationSettings) isEnabled() bool {
+	return logRotation.maxSize > 0 || logRotation.rotateInterval != RotateNever
+}
+
+// hasBackgroundTask reports whether rotated files need compression or retention handling
+func (logRotation rotationSettings) hasBackgroundTask() bool {
+	return logRotation.compressFiles || logRotation.maxBackups > 0 || logRotation.maxAge > 0
+}
+
+// periodStart returns the start of the rotation period containing the selected time
+func (logRotation rotationSettings) periodStart(getTime time.Time) time.Time {
+	switch logRotation.rotateInterval {
+	case RotateHourly:
+		return time.Date(getTime.Year(), getTime.Month(), getTime.Day(), getTime.Hour(), 0, 0, 0, getTime.Location())
+
+	case RotateDaily:
+		return time.Date(getTime.Year(), getTime.Month(), getTime.Day(), 0, 0, 0, 0, getTime.Location())
+	}
+
+	return time.Time{}
+}
+
+// rotateWriter writes to a log file and rotates it once a rotation condition is met
+// Rotation is only checked between log messages so a message is never split across files
+// Log instances initialized with a file path always write through it so the file can be reopened
+type rotateWriter struct {
+	filePath        string           // filePath is the path of the active log file
+	appendOutput    bool             // appendOutput selects appending to the active log file on open
+	fileDescriptor  *os.File         // fileDescriptor is the active log file
+	fileSize        int64            // fileSize is the number of bytes in the active log file
+	filePeriod      time.Time        // filePeriod is the start of the rotation period of the active log file
+	logRotation     rotationSettings // logRotation holds the rotation conditions
+	backgroundGroup sync.WaitGroup   // backgroundGroup tracks the running compression and retention tasks
+	fileLost        bool             // fileLost reports that no log file could be opened after a rotation
+}
+
+// entryRotator is implemented by writers that rotate between log messages
+type entryRotator interface {
+	needsRotation(pendingSize int64) bool
+	rotateIfNeeded() error
+}
+
+// fileProvider is implemented by writers that write to a log file
+type fileProvider interface {
+	currentFile() *os.File
+}
+
+// WithMaxSize rotates the log file once it grows beyond maxSize bytes
+// The rotated file is renamed with a numeric suffix, the newest being .1
+func WithMaxSize(maxSize int64) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logRotation.maxSize = maxSize
+	}
+}
+
+// WithRotationInterval rotates the log file on the selected schedule
+// The rotated file is named by formatting the start of its period with filePattern, a layout
+// of the time package such as "Logs/app-2006-01-02.log". If filePattern is empty, the date is
+// inserted before the extension of the log file, for example app-2024-05-01.log
+func WithRotationInterval(rotateInterval RotationInterval, filePattern string) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logRotation.rotateInterval = rotateInterval
+		logInstance.logRotation.filePattern = filePattern
+	}
+}
+
+// newRotateWriter wraps the opened log file for rotation
+func newRotateWriter(filePath string, appendOutput bool, fileDescriptor *os.File,
+	logRotation rotationSettings) (*rotateWriter, error) {
+	fileInformation, statError := fileDescriptor.Stat()
+
+	if statError != nil {
+		return nil, statError
+	}
+
+	filePeriod := logRotation.periodStart(logRotation.logClock())
+
+	if fileInformation.Size() > 0 {
+		filePeriod = logRotation.periodStart(fileInformation.ModTime())
+	}
+
+	return &rotateWriter{
+		filePath:       filePath,
+		appendOutput:   appendOutput,
+		fileDescriptor: fileDescriptor,
+		fileSize:       fileInformation.Size(),
+		filePeriod:     filePeriod,
+		logRotation:    logRotation,
+	}, nil
+}
+
+// Write writes to the active log file
+func (logRotate *rotateWriter) Write(writeContent []byte) (int, error) {
+	writtenSize, writeError := logRotate.fileDescriptor.Write(writeContent)
+	logRotate.fileSize += int64(writtenSize)
+
+	return writtenSize, writeError
+}
+
+// Sync commits the active log file to disk
+func (logRotate *rotateWriter) Sync() error {
+	return logRotate.fileDescriptor.Sync()
+}
+
+// Close waits for the background tasks and closes the active log file
+func (logRotate *rotateWriter) Close() error {
+	logRotate.backgroundGroup.Wait()
+
+	return logRotate.fileDescriptor.Close()
+}
+
+// currentFile returns the active log file
+func (logRotate *rotateWriter) currentFile() *os.File {
+	return logRotate.fileDescriptor
+}
+
+// needsRotation reports whether a rotation condition is met
+// The pending size counts output that is buffered but not yet written to the log file
+func (logRotate *rotateWriter) needsRotation(pendingSize int64) bool {
+	if logRotate.fileLost {
+		return true
+	}
+
+	if logRotate.logRotation.rotateInterval != RotateNever &&
+		!logRotate.logRotation.periodStart(logRotate.logRotation.logClock()).Equal(logRotate.filePeriod) {
+		return true
+	}
+
+	return logRotate.logRotation.maxSize > 0 && logRotate.fileSize+pendingSize >= logRotate.logRotation.maxSize
+}
+
+// rotateIfNeeded rotates the log file when a rotation condition is met
+// After a rotation left no log file open, the log file path is opened again instead
+func (logRotate *rotateWriter) rotateIfNeeded() error {
+	if logRotate.fileLost {
+		return logRotate.restoreFile(logRotate.filePath)
+	}
+
+	if logRotate.logRotation.rotateInterval != RotateNever {
+		currentPeriod := logRotate.logRotation.periodStart(logRotate.logRotation.logClock())
+
+		if !currentPeriod.Equal(logRotate.filePeriod) {
+			rotatedPath := logRotate.rotatedPath(logRotate.filePeriod)
+			logRotate.filePeriod = currentPeriod
+
+			return logRotate.rotate(func(filePath string) (string, error) {
+				return rotatedPath, os.Rename(filePath, rotatedPath)
+			})
+		}
+	}
+
+	if logRotate.logRotation.maxSize > 0 && logRotate.fileSize >= logRotate.logRotation.maxSize {
+		return logRotate.rotate(shiftBackups)
+	}
+
+	return nil
+}
+
+// rotate closes the active log file, moves it away and opens a fresh one
+// If the log file cannot be moved, it is reopened in append mode
+// If the fresh log file cannot be opened, the moved file is reopened so the output is never written to a closed file
+// The moved file is compressed and the retention limits are applied in the background
+func (logRotate *rotateWriter) rotate(moveFile func(filePath string) (string, error)) error {
+	logRotate.backgroundGroup.Wait()
+
+	if closeError := logRotate.fileDescriptor.Close(); closeError != nil {
+		return closeError
+	}
+
+	movedPath, renameError := moveFile(logRotate.filePath)
+	fileDescriptor, openError := openFile(logRotate.filePath, renameError != nil)
+
+	if openError != nil {
+		// Keep writing to the moved file, without compressing or pruning it
+
+		if renameError != nil {
+			movedPath = logRotate.filePath
+		}
+
+		return errors.Join(openError, logRotate.restoreFile(movedPath))
+	}
+
+	if renameError == nil && logRotate.logRotation.hasBackgroundTask() {
+		logRotate.backgroundGroup.Add(1)
+
+		go func() {
+			defer logRotate.backgroundGroup.Done()
+
+			if logRotate.logRotation.compressFiles {
+				if compressError := compressFile(movedPath); compressError != nil {
+					logRotate.logRotation.reportDiagnostic(DiagnosticRotation, "unable to compress the rotated log file", compressError)
+				}
+			}
+
+			if logRotate.logRotation.maxBackups > 0 || logRotate.logRotation.maxAge > 0 {
+				if pruneError := logRotate.pruneBackups(movedPath); pruneError != nil {
+					logRotate.logRotation.reportDiagnostic(DiagnosticRotation, "unable to delete the expired log files", pruneError)
+				}
+			}
+		}()
+	}
+
+	fileInformation, statError := fileDescriptor.Stat()
+
+	if statError != nil {
+		fileDescriptor.Close()
+		return errors.Join(statError, logRotate.restoreFile(logRotate.filePath))
+	}
+
+	logRotate.fileDescriptor = fileDescriptor
+	logRotate.fileSize = fileInformation.Size()
+
+	return renameError
+}
+
+// restoreFile opens the file at the path in append mode as the active log file once a rotation closed the previous one
+// If it cannot be opened either, the log file is marked as lost and opened again before the next message
+func (logRotate *rotateWriter) restoreFile(filePath string) error {
+	fileDescriptor, openError := openFile(filePath, true)
+
+	if openError != nil {
+		logRotate.fileLost = true
+		return openError
+	}
+
+	logRotate.fileDescriptor = fileDescriptor
+	logRotate.fileLost = false
+
+	if fileInformation, statError := fileDescriptor.Stat(); statError == nil {
+		logRotate.fileSize = fileInformation.Size()
+	}
+
+	return nil
+}
+
+// rotatedPath returns an unused path for the log file of the selected rotation period
+func (logRotate *rotateWriter) rotatedPath(filePeriod time.Time) string {
+	var rotatedPath string
+
+	if logRotate.logRotation.filePattern != "" {
+		rotatedPath = filePeriod.Format(logRotate.logRotation.filePattern)
+	} else {
+		periodLayout := "2006-01-02"
+
+		if logRotate.logRotation.rotateInterval == RotateHourly {
+			periodLayout = "2006-01-02-15"
+		}
+
+		fileExtension := filepath.Ext(logRotate.filePath)
+		rotatedPath = strings.TrimSuffix(logRotate.filePath, fileExtension) + "-" +
+			filePeriod.Format(periodLayout) + fileExtension
+	}
+
+	if _, backupExists := existingBackup(rotatedPath); !backupExists {
+		return rotatedPath
+	}
+
+	return rotatedPath + "." + strconv.Itoa(unusedIndex(rotatedPath))
+}
+
+// shiftBackups renames every numbered backup to the next index and the log file to .1
+// Compressed backups keep their extension while being shifted
+func shiftBackups(filePath string) (string, error) {
+	lastIndex := unusedIndex(filePath)
+
+	for backupIndex := lastIndex; backupIndex > 1; backupIndex-- {
+		olderPrefix := filePath + "." + strconv.Itoa(backupIndex-1)
+		olderPath, _ := existingBackup(olderPrefix)
+		newerPath := filePath + "." + strconv.Itoa(backupIndex) + strings.TrimPrefix(olderPath, olderPrefix)
+
+		if renameError := os.Rename(olderPath, newerPath); renameError != nil {
+			return "", renameError
+		}
+	}
+
+	return filePath + ".1", os.Rename(filePath, filePath+".1")
+}
+
+// rotateOutput rotates the file output of the log instance when a rotation condition is met
+// Rotation failures are reported as diagnostics and the active file keeps receiving output
+func rotateOutput(logInstance *LogInstance) {
+	logRotate, isRotator := logInstance.logOutput.logWriter.(entryRotator)
+
+	if !isRotator {
+		return
+	}
+
+	if rotateError := logRotate.rotateIfNeeded(); rotateError != nil {
+		logInstance.diagnose(DiagnosticRotation, "unable to rotate the log file", rotateError)
+	}
+}
+
+// fileExists reports whether a file exists at the selected path
+func fileExists(filePath string) bool {
+	_, statError := os.Stat(filePath)
+
+	return statError == nil
+}
+
+// unusedIndex returns the first numeric suffix without a rotated file for the selected path
+func unusedIndex(filePath string) int {
+	backupIndex := 1
+
+	for {
+		if _, backupExists := existingBackup(filePath + "." + strconv.Itoa(backupIndex)); !backupExists {
+			return backupIndex
+		}
+
+		backupIndex++
+	}
+}
+
+// existingBackup returns the path of a rotated file either plain or compressed
+func existingBackup(backupPath string) (string, bool) {
+	if fileExists(backupPath) {
+		return backupPath, true
+	}
+
+	if fileExists(backupPath + compressedExtension) {
+		return backupPath + compressedExtension, true
+	}
+
+	return backupPath, false
+}
@@ -0,0 +1,59 @@
+// Per-Level Output Routing
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// routeDestination forwards the log messages within a range of levels to another destination
+type routeDestination struct {
+	targetDestination Destination // targetDestination receives the routed messages
+	minimumLevel      Level       // minimumLevel is the lowest severity routed to the destination
+	maximumLevel      Level       // maximumLevel is the highest severity routed to the destination
+}
+
+// RouteLevels returns a destination forwarding the log messages from minimumLevel to maximumLevel to logDestination
+// For example info and below can go to one file while warnings and above go to another file and the standard error:
+//
+//	appLog, _ := GoLog.NewFileDestination("app.log", GoLog.FormatText, GoLog.LevelTrace)
+//	errorLog, _ := GoLog.NewFileDestination("errors.log", GoLog.FormatText, GoLog.LevelTrace)
+//	GoLog.WithDestination(GoLog.RouteLevels(appLog, GoLog.LevelTrace, GoLog.LevelNormal))
+//	GoLog.WithDestination(GoLog.RouteLevels(errorLog, GoLog.LevelWarning, GoLog.LevelFatal))
+//	GoLog.WithDestination(GoLog.RouteLevels(GoLog.NewWriterDestination(os.Stderr, GoLog.FormatText, GoLog.LevelTrace),
+//		GoLog.LevelWarning, GoLog.LevelFatal))
+func RouteLevels(logDestination Destination, minimumLevel Level, maximumLevel Level) Destination {
+	return &routeDestination{targetDestination: logDestination, minimumLevel: minimumLevel, maximumLevel: maximumLevel}
+}
+
+// WriteEntry forwards the log message when its severity is within the routed range
+func (logRoute *routeDestination) WriteEntry(logEntry Entry) error {
+	return logRoute.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry forwards the log message when its severity is within the routed range
+func (logRoute *routeDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	messageSeverity := messageEntry.messageLevel.levelSeverity
+
+	if messageSeverity < logRoute.minimumLevel || messageSeverity > logRoute.maximumLevel {
+		return nil
+	}
+
+	return writeDestination(logInstance, logRoute.targetDestination, messageEntry)
+}
+
+// Flush flushes the target destination when it buffers its output
+func (logRoute *routeDestination) Flush() error {
+	if bufferedDestination, isBuffered := logRoute.targetDestination.(flushWriter); isBuffered {
+		return bufferedDestination.Flush()
+	}
+
+	return nil
+}
+
+// Close closes the target destination
+func (logRoute *routeDestination) Close() error {
+	return logRoute.targetDestination.Close()
+}
@@ -0,0 +1,140 @@
+// CEF and LEEF Output Formats
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// siemEventKey is the key of the field used as event identifier, the level name is used without it
+const siemEventKey string = "event_id"
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")   // cefHeaderEscaper escapes the header values of CEF
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`) // cefExtensionEscaper escapes the extension values of CEF
+	leefHeaderEscaper   = strings.NewReplacer(`|`, `\|`, "\n", " ", "\r", " ")              // leefHeaderEscaper escapes the header values of LEEF
+	leefValueEscaper    = strings.NewReplacer("\t", " ", "\n", `\n`, "\r", `\r`)            // leefValueEscaper escapes the attribute values of LEEF
+)
+
+// siemFormatter encodes log messages for SIEM platforms in the CEF or LEEF format
+type siemFormatter struct {
+	leefFormat    bool   // leefFormat selects LEEF instead of CEF
+	deviceVendor  string // deviceVendor is the vendor of the product writing the log
+	deviceProduct string // deviceProduct is the name of the product writing the log
+	deviceVersion string // deviceVersion is the version of the product writing the log
+}
+
+// NewCEFFormatter returns a formatter writing log messages in the ArcSight Common Event Format
+// The event identifier is the event_id field or the level name, the message becomes the event name,
+// the level is mapped to a severity from 0 to 10 and the fields are written as extensions
+func NewCEFFormatter(deviceVendor string, deviceProduct string, deviceVersion string) Formatter {
+	return &siemFormatter{deviceVendor: deviceVendor, deviceProduct: deviceProduct, deviceVersion: deviceVersion}
+}
+
+// NewLEEFFormatter returns a formatter writing log messages in the QRadar Log Event Extended Format 1.0
+// The event identifier is the event_id field or the level name and the fields are written as tab separated attributes
+func NewLEEFFormatter(deviceVendor string, deviceProduct string, deviceVersion string) Formatter {
+	return &siemFormatter{leefFormat: true, deviceVendor: deviceVendor, deviceProduct: deviceProduct, deviceVersion: deviceVersion}
+}
+
+// Format writes the log message as a CEF or LEEF event
+func (logSIEM *siemFormatter) Format(logEntry Entry) ([]byte, error) {
+	eventIdentifier := logEntry.LevelName
+
+	for _, entryField := range logEntry.Fields {
+		if entryField.Key == siemEventKey {
+			eventIdentifier = fieldText(entryField)
+		}
+	}
+
+	if logSIEM.leefFormat {
+		return logSIEM.formatLEEF(logEntry, eventIdentifier), nil
+	}
+
+	return logSIEM.formatCEF(logEntry, eventIdentifier), nil
+}
+
+// formatCEF writes the log message as a CEF event
+func (logSIEM *siemFormatter) formatCEF(logEntry Entry, eventIdentifier string) []byte {
+	headerValues := []string{"CEF:0", logSIEM.deviceVendor, logSIEM.deviceProduct, logSIEM.deviceVersion,
+		eventIdentifier, logEntry.Message, strconv.Itoa(siemSeverity(logEntry.Level))}
+
+	for headerIndex := 1; headerIndex < len(headerValues); headerIndex++ {
+		headerValues[headerIndex] = cefHeaderEscaper.Replace(headerValues[headerIndex])
+	}
+
+	extensionPairs := []string{"rt=" + strconv.FormatInt(logEntry.Time.UnixMilli(), 10)}
+
+	for _, entryField := range logEntry.Fields {
+		if entryField.Key != siemEventKey {
+			extensionPairs = append(extensionPairs, siemKey(entryField.Key)+"="+cefExtensionEscaper.Replace(fieldText(entryField)))
+		}
+	}
+
+	return []byte(strings.Join(headerValues, "|") + "|" + strings.Join(extensionPairs, " "))
+}
+
+// formatLEEF writes the log message as a LEEF event
+func (logSIEM *siemFormatter) formatLEEF(logEntry Entry, eventIdentifier string) []byte {
+	headerValues := []string{"LEEF:1.0", logSIEM.deviceVendor, logSIEM.deviceProduct, logSIEM.deviceVersion, eventIdentifier}
+
+	for headerIndex := 1; headerIndex < len(headerValues); headerIndex++ {
+		headerValues[headerIndex] = leefHeaderEscaper.Replace(headerValues[headerIndex])
+	}
+
+	eventAttributes := []string{
+		"devTime=" + strconv.FormatInt(logEntry.Time.UnixMilli(), 10),
+		"sev=" + strconv.Itoa(siemSeverity(logEntry.Level)),
+		"msg=" + leefValueEscaper.Replace(logEntry.Message),
+	}
+
+	for _, entryField := range logEntry.Fields {
+		if entryField.Key != siemEventKey {
+			eventAttributes = append(eventAttributes, siemKey(entryField.Key)+"="+leefValueEscaper.Replace(fieldText(entryField)))
+		}
+	}
+
+	return []byte(strings.Join(headerValues, "|") + "|" + strings.Join(eventAttributes, "\t"))
+}
+
+// siemSeverity maps the level severity to the severity scale from 0 to 10 of CEF and LEEF
+func siemSeverity(levelSeverity Level) int {
+	switch {
+	case levelSeverity >= LevelFatal:
+		return 10
+
+	case levelSeverity >= LevelPanic:
+		return 9
+
+	case levelSeverity >= LevelError:
+		return 7
+
+	case levelSeverity >= LevelWarning:
+		return 5
+
+	case levelSeverity >= LevelNormal:
+		return 3
+
+	default:
+		return 1
+	}
+}
+
+// siemKey converts a field key into an extension key made of letters, digits and underscores
+func siemKey(fieldKey string) string {
+	return strings.Map(func(keyCharacter rune) rune {
+		if (keyCharacter >= 'a' && keyCharacter <= 'z') || (keyCharacter >= 'A' && keyCharacter <= 'Z') ||
+			(keyCharacter >= '0' && keyCharacter <= '9') || keyCharacter == '_' {
+			return keyCharacter
+		}
+
+		return '_'
+	}, fieldKey)
+}
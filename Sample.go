@@ -0,0 +1,100 @@
+// Message Sampling
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"time"
+)
+
+// sampleSuppressedKey is the key of the field counting the identical messages dropped before a sampled one
+const sampleSuppressedKey string = "suppressed"
+
+// messageSampler limits how often identical messages are written within an interval
+// Messages are identical when they share the message type and the message text
+type messageSampler struct {
+	sampleInterval  time.Duration             // sampleInterval is the length of a sampling window
+	firstCount      int                       // firstCount is the number of identical messages written in full per window
+	thereafterCount int                       // thereafterCount selects writing 1 of every thereafterCount later messages
+	windowStart     time.Time                 // windowStart is the time the current sampling window began
+	sampleCounters  map[string]*sampleCounter // sampleCounters holds the counters of the messages seen in the window
+}
+
+// sampleCounter counts the occurrences of a single message
+type sampleCounter struct {
+	seenCount       int // seenCount is the number of occurrences within the current window
+	suppressedCount int // suppressedCount is the number of occurrences dropped since the last written one
+}
+
+// WithSampling writes the first firstCount identical messages of every sampleInterval and then
+// 1 of every thereafterCount, a thereafterCount of zero drops every later message of the interval
+// A written message following dropped ones carries the number of dropped messages in the suppressed field
+// Fatal and panic messages are never dropped
+func WithSampling(sampleInterval time.Duration, firstCount int, thereafterCount int) Option {
+	return func(logInstance *LogInstance) {
+		if sampleInterval <= 0 {
+			logInstance.logSampler = nil
+			return
+		}
+
+		logInstance.logSampler = &messageSampler{
+			sampleInterval:  sampleInterval,
+			firstCount:      firstCount,
+			thereafterCount: thereafterCount,
+			sampleCounters:  make(map[string]*sampleCounter),
+		}
+	}
+}
+
+// admit reports whether the message is written and adds the suppressed field when needed
+// The log instance lock must be held by the caller
+func (logSampler *messageSampler) admit(messageEntry *logEntry) bool {
+	if logSampler == nil || messageEntry.messageType == messageFatal || messageEntry.messageType == MessagePanic {
+		return true
+	}
+
+	// Start a new window, forgetting the messages without pending suppressed occurrences
+
+	if messageEntry.entryTime.Sub(logSampler.windowStart) >= logSampler.sampleInterval ||
+		messageEntry.entryTime.Before(logSampler.windowStart) {
+		logSampler.windowStart = messageEntry.entryTime
+
+		for sampleKey, messageCounter := range logSampler.sampleCounters {
+			if messageCounter.suppressedCount == 0 {
+				delete(logSampler.sampleCounters, sampleKey)
+			} else {
+				messageCounter.seenCount = 0
+			}
+		}
+	}
+
+	sampleKey := messageEntry.messageType + fmt.Sprint(messageEntry.messageParts...)
+	messageCounter, isCounted := logSampler.sampleCounters[sampleKey]
+
+	if !isCounted {
+		messageCounter = &sampleCounter{}
+		logSampler.sampleCounters[sampleKey] = messageCounter
+	}
+
+	messageCounter.seenCount++
+	laterCount := messageCounter.seenCount - logSampler.firstCount
+
+	if laterCount > 0 && (logSampler.thereafterCount <= 0 || laterCount%logSampler.thereafterCount != 0) {
+		messageCounter.suppressedCount++
+		return false
+	}
+
+	if messageCounter.suppressedCount > 0 {
+		messageEntry.entryFields = append(messageEntry.entryFields[:len(messageEntry.entryFields):len(messageEntry.entryFields)],
+			Int(sampleSuppressedKey, messageCounter.suppressedCount))
+		messageCounter.suppressedCount = 0
+	}
+
+	return true
+}
@@ -0,0 +1,264 @@
+// Sentry Error Reporting Hook
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errSentryDSN is returned when the Sentry DSN lacks the public key or the project identifier
+var errSentryDSN = errors.New("the Sentry DSN needs a public key and a project identifier")
+
+// SentryHook reports log messages to Sentry through its envelope API
+// Events are delivered from the background, fatal and panic messages are delivered before the hook returns
+type SentryHook struct {
+	sentryDSN    string       // sentryDSN is the client key address of the Sentry project
+	envelopeURL  string       // envelopeURL is the address of the envelope API of the project
+	minimumLevel Level        // minimumLevel is the minimum severity a message needs to be reported
+	httpSettings HTTPSettings // httpSettings configures the requests
+	logBatch     *batchSender // logBatch collects and delivers the encoded envelopes
+}
+
+// sentryEvent is the event payload of a Sentry envelope
+type sentryEvent struct {
+	EventID   string            `json:"event_id"`            // EventID identifies the event
+	Timestamp string            `json:"timestamp"`           // Timestamp is the message time in RFC 3339 format
+	Level     string            `json:"level"`               // Level is the Sentry level of the message
+	Platform  string            `json:"platform"`            // Platform is the platform of the reporting program
+	Logger    string            `json:"logger"`              // Logger is the name of the reporting logger
+	Message   sentryMessage     `json:"message"`             // Message is the message content
+	Extra     json.RawMessage   `json:"extra,omitempty"`     // Extra holds the fields of the message
+	Exception []sentryException `json:"exception,omitempty"` // Exception holds the error and its stack trace
+}
+
+// sentryMessage is the message interface of a Sentry event
+type sentryMessage struct {
+	Formatted string `json:"formatted"` // Formatted is the message text
+}
+
+// sentryException is a single exception of a Sentry event
+type sentryException struct {
+	Type       string            `json:"type"`                 // Type is the kind of the exception
+	Value      string            `json:"value"`                // Value is the error message
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"` // Stacktrace is the stack trace of the log call
+}
+
+// sentryStacktrace is the stack trace of a Sentry exception
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"` // Frames are the stack frames from the outermost to the innermost call
+}
+
+// sentryFrame is a single frame of a Sentry stack trace
+type sentryFrame struct {
+	Function string `json:"function"` // Function is the fully qualified function name
+	Filename string `json:"filename"` // Filename is the path of the source file
+	Lineno   int    `json:"lineno"`   // Lineno is the line number in the source file
+}
+
+// NewSentryHook returns a hook reporting messages at or above minimumLevel to the Sentry project of the DSN
+// The message, its fields, the error field and the stack trace or the caller are reported,
+// enable WithStacktrace to attach the full stack trace
+// Queued events reach Sentry with the next batch, or sooner when the log instance is flushed or closed
+func NewSentryHook(sentryDSN string, minimumLevel Level, httpSettings HTTPSettings) (*SentryHook, error) {
+	parsedDSN, parseError := url.Parse(sentryDSN)
+
+	if parseError != nil {
+		return nil, parseError
+	}
+
+	projectPath := strings.TrimSuffix(parsedDSN.Path, "/")
+	projectSeparator := strings.LastIndexByte(projectPath, '/')
+	publicKey := parsedDSN.User.Username()
+
+	if publicKey == "" || projectSeparator < 0 || projectSeparator == len(projectPath)-1 {
+		return nil, errSentryDSN
+	}
+
+	requestHeaders := make(map[string]string, len(httpSettings.Headers)+1)
+
+	for headerName, headerValue := range httpSettings.Headers {
+		requestHeaders[headerName] = headerValue
+	}
+
+	requestHeaders["X-Sentry-Auth"] = "Sentry sentry_version=7, sentry_client=golog/1.0, sentry_key=" + publicKey
+	httpSettings.Headers = requestHeaders
+
+	// Deliver every envelope on its own so a retry never sends an event twice
+
+	httpSettings.BatchSize = 1
+
+	sentryHook := &SentryHook{
+		sentryDSN: sentryDSN,
+		envelopeURL: parsedDSN.Scheme + "://" + parsedDSN.Host + projectPath[:projectSeparator] +
+			"/api/" + projectPath[projectSeparator+1:] + "/envelope/",
+		minimumLevel: minimumLevel,
+		httpSettings: httpSettings,
+	}
+
+	sentryHook.logBatch = newBatchSender(httpSettings.BatchSettings, sentryHook.deliver)
+
+	return sentryHook, nil
+}
+
+// Fire queues the entry as a Sentry event when it is severe enough and never drops it
+func (sentryHook *SentryHook) Fire(hookEntry *Entry) bool {
+	if hookEntry.Level < sentryHook.minimumLevel {
+		return true
+	}
+
+	sentryHook.logBatch.add(hookEntry.instance(), sentryHook.encodeEnvelope(hookEntry))
+
+	// Deliver right away since the process ends after a fatal message or a panic
+
+	if hookEntry.Level >= LevelPanic {
+		sentryHook.logBatch.deliverPending()
+	}
+
+	return true
+}
+
+// Flush delivers every pending event
+func (sentryHook *SentryHook) Flush() error {
+	return sentryHook.logBatch.Flush()
+}
+
+// Close delivers every pending event and stops the background delivery
+func (sentryHook *SentryHook) Close() error {
+	return sentryHook.logBatch.Close()
+}
+
+// deliver posts the envelope of the batch
+func (sentryHook *SentryHook) deliver(batchItems []interface{}) error {
+	_, postError := postBatch(sentryHook.envelopeURL, "application/x-sentry-envelope",
+		batchItems[0].([]byte), sentryHook.httpSettings)
+
+	return postError
+}
+
+// encodeEnvelope encodes the entry as a Sentry envelope holding a single event
+func (sentryHook *SentryHook) encodeEnvelope(hookEntry *Entry) []byte {
+	eventIdentifier := make([]byte, 16)
+	rand.Read(eventIdentifier)
+
+	loggedEvent := sentryEvent{
+		EventID:   hex.EncodeToString(eventIdentifier),
+		Timestamp: hookEntry.Time.UTC().Format(time.RFC3339Nano),
+		Level:     sentryLevel(hookEntry.Level),
+		Platform:  "go",
+		Logger:    "golog",
+		Message:   sentryMessage{Formatted: hookEntry.Message},
+	}
+
+	if len(hookEntry.Fields) > 0 {
+		extraBuffer := []byte{'{'}
+
+		for fieldIndex, entryField := range hookEntry.Fields {
+			if fieldIndex > 0 {
+				extraBuffer = append(extraBuffer, ',')
+			}
+
+			extraBuffer = appendJSONField(extraBuffer, entryField.Key, entryField)
+		}
+
+		loggedEvent.Extra = append(extraBuffer, '}')
+	}
+
+	exceptionType, exceptionValue := "log", hookEntry.Message
+
+	for _, entryField := range hookEntry.Fields {
+		if entryField.valueType == fieldError && entryField.interfaceValue != nil {
+			exceptionType, exceptionValue = "error", fieldText(entryField)
+		}
+	}
+
+	if stackFrames := sentryFrames(hookEntry); len(stackFrames) > 0 {
+		loggedEvent.Exception = []sentryException{{
+			Type:       exceptionType,
+			Value:      exceptionValue,
+			Stacktrace: &sentryStacktrace{Frames: stackFrames},
+		}}
+	}
+
+	encodedEvent, _ := json.Marshal(loggedEvent)
+	encodedHeader, _ := json.Marshal(map[string]string{
+		"event_id": loggedEvent.EventID,
+		"dsn":      sentryHook.sentryDSN,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+	})
+
+	envelopeBuffer := append(encodedHeader, '\n')
+	envelopeBuffer = append(envelopeBuffer, `{"type":"event","length":`...)
+	envelopeBuffer = strconv.AppendInt(envelopeBuffer, int64(len(encodedEvent)), 10)
+	envelopeBuffer = append(envelopeBuffer, "}\n"...)
+	envelopeBuffer = append(envelopeBuffer, encodedEvent...)
+
+	return append(envelopeBuffer, '\n')
+}
+
+// sentryFrames returns the stack trace of the entry as Sentry frames from the outermost call
+// Without a stack trace, the caller becomes the only frame
+func sentryFrames(hookEntry *Entry) []sentryFrame {
+	var stackFrames []sentryFrame
+
+	stackLines := strings.Split(hookEntry.Stack, "\n")
+
+	for lineIndex := 0; hookEntry.Stack != "" && lineIndex+1 < len(stackLines); lineIndex += 2 {
+		frameLocation := strings.TrimPrefix(stackLines[lineIndex+1], "\t")
+		lineSeparator := strings.LastIndexByte(frameLocation, ':')
+
+		if lineSeparator < 0 {
+			continue
+		}
+
+		lineNumber, _ := strconv.Atoi(frameLocation[lineSeparator+1:])
+
+		stackFrames = append([]sentryFrame{{
+			Function: stackLines[lineIndex],
+			Filename: frameLocation[:lineSeparator],
+			Lineno:   lineNumber,
+		}}, stackFrames...)
+	}
+
+	if len(stackFrames) == 0 && hookEntry.Caller != nil {
+		stackFrames = []sentryFrame{{
+			Function: hookEntry.Caller.Function,
+			Filename: hookEntry.Caller.File,
+			Lineno:   hookEntry.Caller.Line,
+		}}
+	}
+
+	return stackFrames
+}
+
+// sentryLevel returns the Sentry level matching the severity
+func sentryLevel(levelSeverity Level) string {
+	switch {
+	case levelSeverity >= LevelFatal:
+		return "fatal"
+
+	case levelSeverity >= LevelError:
+		return "error"
+
+	case levelSeverity >= LevelWarning:
+		return "warning"
+
+	case levelSeverity >= LevelNormal:
+		return "info"
+
+	default:
+		return "debug"
+	}
+}
@@ -0,0 +1,66 @@
+// Graceful Shutdown
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errShutdown is returned when an audit event is written by a log instance that was shut down
+var errShutdown = errors.New("log instance was shut down")
+
+// Shutdown stops the log instance for good as the last step of a service shutdown
+// New messages of the log instance and its child loggers are dropped right away, then the queued messages
+// are written, the buffers flushed and the file, the destinations, the audit trail and the hooks that
+// implement io.Closer are closed
+// When the context ends first, its error is returned while the remaining work goes on in the background
+func (logInstance *LogInstance) Shutdown(shutdownContext context.Context) error {
+	logInstance.logStopped.Store(true)
+
+	shutdownDone := make(chan error, 1)
+
+	go func() {
+		shutdownError := logInstance.Close()
+
+		if hookError := closeHooks(logInstance); shutdownError == nil {
+			shutdownError = hookError
+		}
+
+		shutdownDone <- shutdownError
+	}()
+
+	select {
+	case shutdownError := <-shutdownDone:
+		return shutdownError
+
+	case <-shutdownContext.Done():
+		return fmt.Errorf("log instance did not shut down in time: %w", shutdownContext.Err())
+	}
+}
+
+// closeHooks closes the hooks of the log instance that implement io.Closer
+func closeHooks(logInstance *LogInstance) error {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	var closeError error
+
+	for _, logHook := range logInstance.logHooks {
+		if hookCloser, isCloser := logHook.(io.Closer); isCloser {
+			if hookError := hookCloser.Close(); hookError != nil && closeError == nil {
+				closeError = hookError
+			}
+		}
+	}
+
+	return closeError
+}
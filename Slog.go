@@ -0,0 +1,196 @@
+// Standard Library Structured Logging Handler
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+// SlogHandler routes log/slog records through a log instance
+// Records are written to the configured destinations of the log instance
+type SlogHandler struct {
+	logInstance *LogInstance // logInstance is the log instance receiving the records
+	boundFields []Field      // boundFields holds the attributes added with WithAttrs
+	groupPrefix string       // groupPrefix is prepended to the keys of the record attributes
+}
+
+// NewSlogHandler returns a log/slog handler writing through the log instance
+func NewSlogHandler(logInstance *LogInstance) *SlogHandler {
+	return &SlogHandler{logInstance: logInstance}
+}
+
+// Enabled reports whether the handler writes records at the slog level
+func (slogHandler *SlogHandler) Enabled(_ context.Context, slogLevel slog.Level) bool {
+	messageLevel := lookupLevel(slogMessageType(slogLevel))
+
+	slogHandler.logInstance.logLock.Lock()
+	defer slogHandler.logInstance.logLock.Unlock()
+
+	return slogHandler.logInstance.levelEnabled(messageLevel.levelSeverity)
+}
+
+// Handle writes the slog record to the configured destinations
+// The time and the source location of the record are kept, and the fields of the context are added
+func (slogHandler *SlogHandler) Handle(recordContext context.Context, slogRecord slog.Record) error {
+	logInstance := slogHandler.logInstance
+	messageType := slogMessageType(slogRecord.Level)
+	messageLevel := lookupLevel(messageType)
+
+	recordFields := append([]Field{}, slogHandler.boundFields...)
+	recordFields = append(recordFields, contextFields(recordContext)...)
+
+	slogRecord.Attrs(func(slogAttr slog.Attr) bool {
+		recordFields = appendSlogAttr(recordFields, slogHandler.groupPrefix, slogAttr)
+		return true
+	})
+
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	// Drop records below the selected level or after a shutdown
+
+	if !logInstance.levelEnabled(messageLevel.levelSeverity) || logInstance.logStopped.Load() {
+		return nil
+	}
+
+	entryTime := slogRecord.Time
+
+	if entryTime.IsZero() {
+		entryTime = logInstance.logClock()
+	}
+
+	entryFields, _ := collectFields(logInstance.boundFields, nil, nil)
+
+	messageEntry := logEntry{
+		entryTime:    entryTime,
+		messageType:  messageType,
+		messageLevel: messageLevel,
+		entryFields:  append(entryFields, recordFields...),
+		messageParts: []interface{}{slogRecord.Message},
+	}
+
+	evaluateLazy(messageEntry.entryFields, nil)
+
+	if logInstance.captureCaller && slogRecord.PC != 0 {
+		callerFrame, _ := runtime.CallersFrames([]uintptr{slogRecord.PC}).Next()
+
+		messageEntry.entryCaller = &callerInformation{
+			callerFile:     callerFrame.File,
+			callerLine:     callerFrame.Line,
+			callerFunction: callerFrame.Function,
+		}
+	}
+
+	if logInstance.captureStack && messageLevel.levelSeverity >= logInstance.stackLevel {
+		messageEntry.entryStack = captureStack()
+	}
+
+	dispatchEntry(logInstance, logInstance.fileOutput, logInstance.terminalOutput,
+		logInstance.colorOutput, messageEntry)
+
+	return nil
+}
+
+// WithAttrs returns a handler adding the attributes to every record
+func (slogHandler *SlogHandler) WithAttrs(slogAttrs []slog.Attr) slog.Handler {
+	childHandler := *slogHandler
+	childHandler.boundFields = append([]Field{}, slogHandler.boundFields...)
+
+	for _, slogAttr := range slogAttrs {
+		childHandler.boundFields = appendSlogAttr(childHandler.boundFields, slogHandler.groupPrefix, slogAttr)
+	}
+
+	return &childHandler
+}
+
+// WithGroup returns a handler prefixing the keys of the following attributes with the group name
+func (slogHandler *SlogHandler) WithGroup(groupName string) slog.Handler {
+	if groupName == "" {
+		return slogHandler
+	}
+
+	childHandler := *slogHandler
+	childHandler.groupPrefix = slogHandler.groupPrefix + groupName + "."
+
+	return &childHandler
+}
+
+// slogMessageType returns the message type matching the slog level
+func slogMessageType(slogLevel slog.Level) string {
+	switch {
+	case slogLevel >= slog.LevelError:
+		return MessageError
+
+	case slogLevel >= slog.LevelWarn:
+		return MessageWarning
+
+	case slogLevel >= slog.LevelInfo:
+		return MessageNormal
+
+	case slogLevel >= slog.LevelDebug:
+		return MessageDebug
+
+	default:
+		return MessageTrace
+	}
+}
+
+// appendSlogAttr converts the slog attribute into fields and appends them
+// Group attributes are flattened into keys joined with a dot
+func appendSlogAttr(logFields []Field, groupPrefix string, slogAttr slog.Attr) []Field {
+	slogValue := slogAttr.Value.Resolve()
+
+	if slogAttr.Equal(slog.Attr{}) {
+		return logFields
+	}
+
+	if slogValue.Kind() == slog.KindGroup {
+		nestedPrefix := groupPrefix
+
+		if slogAttr.Key != "" {
+			nestedPrefix += slogAttr.Key + "."
+		}
+
+		for _, nestedAttr := range slogValue.Group() {
+			logFields = appendSlogAttr(logFields, nestedPrefix, nestedAttr)
+		}
+
+		return logFields
+	}
+
+	fieldKey := groupPrefix + slogAttr.Key
+
+	switch slogValue.Kind() {
+	case slog.KindString:
+		return append(logFields, String(fieldKey, slogValue.String()))
+
+	case slog.KindInt64:
+		return append(logFields, Int64(fieldKey, slogValue.Int64()))
+
+	case slog.KindUint64:
+		return append(logFields, Any(fieldKey, slogValue.Uint64()))
+
+	case slog.KindFloat64:
+		return append(logFields, Float64(fieldKey, slogValue.Float64()))
+
+	case slog.KindBool:
+		return append(logFields, Bool(fieldKey, slogValue.Bool()))
+
+	case slog.KindDuration:
+		return append(logFields, Duration(fieldKey, slogValue.Duration()))
+
+	case slog.KindTime:
+		return append(logFields, Time(fieldKey, slogValue.Time()))
+
+	default:
+		return append(logFields, Any(fieldKey, slogValue.Any()))
+	}
+}
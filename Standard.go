@@ -0,0 +1,44 @@
+// Standard Library Logger Adapter
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"io"
+	"log"
+	"strings"
+)
+
+// levelWriter writes every line it receives as a log message of a single message type
+type levelWriter struct {
+	logInstance *LogInstance // logInstance is the log instance receiving the messages
+	messageType string       // messageType is the type of the written messages
+}
+
+// StdLogger returns a standard library logger writing into the log instance
+// Every line printed by the logger becomes a message of the given message type
+func (logInstance *LogInstance) StdLogger(messageType string) *log.Logger {
+	return log.New(logInstance.Writer(messageType), "", 0)
+}
+
+// Writer returns a writer logging every line written to it as a message of the given message type
+// The messages are written to the configured destinations
+func (logInstance *LogInstance) Writer(messageType string) io.Writer {
+	return &levelWriter{logInstance: logInstance, messageType: messageType}
+}
+
+// Write logs every line of the content as a separate message
+func (writer *levelWriter) Write(writeContent []byte) (int, error) {
+	messageText := strings.TrimRight(string(writeContent), "\r\n")
+
+	for _, messageLine := range strings.Split(messageText, "\n") {
+		printConfigured(writer.logInstance, writer.messageType, nil, strings.TrimSuffix(messageLine, "\r"))
+	}
+
+	return len(writeContent), nil
+}
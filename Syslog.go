@@ -0,0 +1,65 @@
+// Syslog Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build !windows && !plan9
+
+package GoLog
+
+import "log/syslog"
+
+// SyslogDestination writes log messages to a local or remote syslog daemon
+// The level of every message is mapped to the matching syslog severity
+type SyslogDestination struct {
+	syslogWriter *syslog.Writer // syslogWriter is the connection to the syslog daemon
+}
+
+// NewSyslogDestination connects to the syslog daemon at syslogAddress over syslogNetwork
+// If syslogNetwork is empty, it connects to the local syslog daemon
+// Messages are sent with the user facility and tagged with syslogTag
+func NewSyslogDestination(syslogNetwork string, syslogAddress string, syslogTag string) (*SyslogDestination, error) {
+	syslogWriter, dialError := syslog.Dial(syslogNetwork, syslogAddress, syslog.LOG_USER|syslog.LOG_INFO, syslogTag)
+
+	if dialError != nil {
+		return nil, dialError
+	}
+
+	return &SyslogDestination{syslogWriter: syslogWriter}, nil
+}
+
+// WriteEntry sends the log message to the syslog daemon with the severity of its level
+func (syslogDestination *SyslogDestination) WriteEntry(logEntry Entry) error {
+	return syslogDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry sends the log message to the syslog daemon with the severity of its level
+func (syslogDestination *SyslogDestination) writeEntry(_ *LogInstance, messageEntry logEntry) error {
+	messageText := entryText(messageEntry)
+	messageSeverity := messageEntry.messageLevel.levelSeverity
+
+	switch {
+	case messageSeverity >= LevelPanic:
+		return syslogDestination.syslogWriter.Crit(messageText)
+
+	case messageSeverity >= LevelError:
+		return syslogDestination.syslogWriter.Err(messageText)
+
+	case messageSeverity >= LevelWarning:
+		return syslogDestination.syslogWriter.Warning(messageText)
+
+	case messageSeverity >= LevelNormal:
+		return syslogDestination.syslogWriter.Info(messageText)
+
+	default:
+		return syslogDestination.syslogWriter.Debug(messageText)
+	}
+}
+
+// Close closes the connection to the syslog daemon
+func (syslogDestination *SyslogDestination) Close() error {
+	return syslogDestination.syslogWriter.Close()
+}
@@ -0,0 +1,34 @@
+// OpenTelemetry Trace Correlation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceFields returns the trace and span identifiers of the span active in the context
+// Nothing is returned if the context carries no valid span
+func traceFields(parentContext context.Context) []Field {
+	if parentContext == nil {
+		return nil
+	}
+
+	spanContext := trace.SpanContextFromContext(parentContext)
+
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return []Field{
+		String("trace_id", spanContext.TraceID().String()),
+		String("span_id", spanContext.SpanID().String()),
+	}
+}
@@ -0,0 +1,167 @@
+// Template Driven Output Format
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// patternPlaceholders are the names of the placeholders a pattern may hold besides %field:key%
+var patternPlaceholders = map[string]bool{
+	"time": true, "level": true, "label": true, "caller": true, "func": true, "msg": true, "fields": true, "stack": true,
+}
+
+// patternFormatter encodes log messages by filling the placeholders of a pattern
+type patternFormatter struct {
+	patternParts []string // patternParts alternates literal text and placeholder names, starting with literal text
+}
+
+// templateFormatter encodes log messages by executing a text template with the Entry as data
+type templateFormatter struct {
+	lineTemplate *template.Template // lineTemplate renders a single line
+}
+
+// NewPatternFormatter returns a formatter filling the placeholders of linePattern for every message
+// The placeholders are %time%, %level%, %label%, %caller%, %func%, %msg%, %fields%, %stack%
+// and %field:key% for the value of a single field, for example "%time% %level% %caller% %msg% %fields%"
+// The fields are written as key=value pairs, %% writes a percent sign and any other percent sign is written
+// unchanged, so "100% done %msg%" keeps its literal percent sign
+func NewPatternFormatter(linePattern string) Formatter {
+	var patternParts []string
+	var literalText strings.Builder
+
+	for {
+		placeholderStart := strings.IndexByte(linePattern, '%')
+
+		if placeholderStart < 0 {
+			break
+		}
+
+		literalText.WriteString(linePattern[:placeholderStart])
+		linePattern = linePattern[placeholderStart+1:]
+
+		if strings.HasPrefix(linePattern, "%") {
+			literalText.WriteByte('%')
+			linePattern = linePattern[1:]
+
+			continue
+		}
+
+		placeholderName, remainingPattern, isClosed := strings.Cut(linePattern, "%")
+
+		if !isClosed || !isPlaceholder(placeholderName) {
+			literalText.WriteByte('%')
+			continue
+		}
+
+		patternParts = append(patternParts, literalText.String(), placeholderName)
+		literalText.Reset()
+		linePattern = remainingPattern
+	}
+
+	literalText.WriteString(linePattern)
+
+	return &patternFormatter{patternParts: append(patternParts, literalText.String())}
+}
+
+// isPlaceholder reports whether the name between two percent signs of a pattern is a known placeholder
+func isPlaceholder(placeholderName string) bool {
+	fieldKey, isField := strings.CutPrefix(placeholderName, "field:")
+	return patternPlaceholders[placeholderName] || isField && fieldKey != ""
+}
+
+// NewTemplateFormatter returns a formatter executing lineTemplate with the Entry of every message as data,
+// for example template.Must(template.New("line").Parse(`{{.Time.Format "15:04:05"}} {{.LevelName}} {{.Message}}`))
+func NewTemplateFormatter(lineTemplate *template.Template) Formatter {
+	return &templateFormatter{lineTemplate: lineTemplate}
+}
+
+// Format fills the placeholders of the pattern with the log message
+func (logPattern *patternFormatter) Format(logEntry Entry) ([]byte, error) {
+	var lineBuilder strings.Builder
+
+	for partIndex, patternPart := range logPattern.patternParts {
+		if partIndex%2 == 0 {
+			lineBuilder.WriteString(patternPart)
+			continue
+		}
+
+		lineBuilder.WriteString(placeholderValue(logEntry, patternPart))
+	}
+
+	return []byte(lineBuilder.String()), nil
+}
+
+// Format executes the template with the log message
+func (logTemplate *templateFormatter) Format(logEntry Entry) ([]byte, error) {
+	var lineBuffer bytes.Buffer
+
+	if executeError := logTemplate.lineTemplate.Execute(&lineBuffer, logEntry); executeError != nil {
+		return nil, executeError
+	}
+
+	return lineBuffer.Bytes(), nil
+}
+
+// placeholderValue returns the value of the placeholder for the log message
+func placeholderValue(logEntry Entry, placeholderName string) string {
+	switch placeholderName {
+	case "time":
+		return generateTimestamp(logEntry.instance(), logEntry.Time)
+
+	case "level":
+		return logEntry.LevelName
+
+	case "label":
+		return strings.TrimSpace(logEntry.instance().levelLabel(logEntry.internalType()))
+
+	case "caller":
+		if logEntry.Caller == nil {
+			return ""
+		}
+
+		entryCaller := callerInformation{callerFile: logEntry.Caller.File, callerLine: logEntry.Caller.Line}
+
+		return entryCaller.shortFile()
+
+	case "func":
+		if logEntry.Caller == nil {
+			return ""
+		}
+
+		return logEntry.Caller.Function
+
+	case "msg":
+		return logEntry.Message
+
+	case "fields":
+		fieldPairs := make([]string, 0, len(logEntry.Fields))
+
+		for _, entryField := range logEntry.Fields {
+			fieldPairs = append(fieldPairs, entryField.Key+"="+fieldText(entryField))
+		}
+
+		return strings.Join(fieldPairs, " ")
+
+	case "stack":
+		return logEntry.Stack
+	}
+
+	fieldKey := strings.TrimPrefix(placeholderName, "field:")
+
+	for _, entryField := range logEntry.Fields {
+		if entryField.Key == fieldKey {
+			return fieldText(entryField)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,41 @@
+// Template Driven Output Format Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPatternFormatterPercentSigns(t *testing.T) {
+	patternEntry := Entry{
+		Time:      time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Level:     LevelNormal,
+		LevelName: "info",
+		Message:   "upload",
+		Fields:    []Field{Int("progress", 100)},
+	}
+
+	for _, testCase := range []struct {
+		linePattern  string // linePattern is the pattern of the formatter
+		expectedLine string // expectedLine is the line the pattern encodes the entry to
+	}{
+		{linePattern: "100% done %msg%", expectedLine: "100% done upload"},
+		{linePattern: "%level% %msg% at %field:progress%%%", expectedLine: "info upload at 100%"},
+		{linePattern: "%%msg%% %msg%", expectedLine: "%msg% upload"},
+		{linePattern: "%unknown% %msg% 50%", expectedLine: "%unknown% upload 50%"},
+		{linePattern: "%msg", expectedLine: "%msg"},
+	} {
+		encodedLine, _ := NewPatternFormatter(testCase.linePattern).Format(patternEntry)
+
+		if string(encodedLine) != testCase.expectedLine {
+			t.Errorf("pattern %q encoded %q, want %q", testCase.linePattern, encodedLine, testCase.expectedLine)
+		}
+	}
+}
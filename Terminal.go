@@ -0,0 +1,113 @@
+// Terminal Color Detection
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// ColorMode selects when colored terminal output is written
+type ColorMode int
+
+const (
+	ColorModeAuto   ColorMode = iota // ColorModeAuto colors the output only when the stream is a terminal and the environment allows it
+	ColorModeAlways                  // ColorModeAlways colors the output whenever colors are requested
+	ColorModeNever                   // ColorModeNever never colors the output
+)
+
+// terminalStreams caches whether a file is a terminal
+var terminalStreams sync.Map
+
+// WithColorMode selects when colors requested for the terminal output are written
+// By default colors are dropped when the stream is not a terminal, such as a pipe, a CI log or a cron mail,
+// when NO_COLOR is set or when TERM is dumb, and kept whenever FORCE_COLOR is set
+func WithColorMode(colorMode ColorMode) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.colorMode = colorMode
+	}
+}
+
+// colorEnabled reports whether colors requested for the terminal writer are written
+func (logInstance *LogInstance) colorEnabled(needTerminalColoredOutput bool, terminalWriter io.Writer) bool {
+	if !needTerminalColoredOutput {
+		return false
+	}
+
+	switch logInstance.colorMode {
+	case ColorModeAlways:
+		ansiTerminal(terminalWriter)
+		return true
+
+	case ColorModeNever:
+		return false
+
+	default:
+		return colorSupported(terminalWriter)
+	}
+}
+
+// colorSupported reports whether the environment and the writer allow colored output
+func colorSupported(terminalWriter io.Writer) bool {
+	if os.Getenv("FORCE_COLOR") != "" {
+		ansiTerminal(terminalWriter)
+		return true
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return ansiTerminal(terminalWriter)
+}
+
+// ansiTerminal reports whether the writer is a terminal displaying ANSI escape sequences
+// The answer is cached per file, and on Windows the first check enables the escape sequences on the console
+func ansiTerminal(terminalWriter io.Writer) bool {
+	terminalFile, isFile := terminalWriter.(*os.File)
+
+	if !isFile {
+		return false
+	}
+
+	if isTerminal, isCached := terminalStreams.Load(terminalFile); isCached {
+		return isTerminal.(bool)
+	}
+
+	isTerminal := prepareTerminal(terminalFile)
+
+	terminalStreams.Store(terminalFile, isTerminal)
+
+	return isTerminal
+}
+
+// WithLevelColor colors terminal messages of the message type with colorCode instead of the color of its level,
+// for example WithLevelColor(MessageNormal, ColorGreen)
+func WithLevelColor(messageType string, colorCode string) Option {
+	return func(logInstance *LogInstance) {
+		levelColors := make(map[string]string, len(logInstance.levelColors)+1)
+
+		for colorType, typeColor := range logInstance.levelColors {
+			levelColors[colorType] = typeColor
+		}
+
+		levelColors[messageType] = colorCode
+		logInstance.levelColors = levelColors
+	}
+}
+
+// levelColor returns the color selected for the message type, or defaultColor without a replacement
+func (logInstance *LogInstance) levelColor(messageType string, defaultColor string) string {
+	if colorCode, isReplaced := logInstance.levelColors[messageType]; isReplaced {
+		return colorCode
+	}
+
+	return defaultColor
+}
@@ -0,0 +1,20 @@
+// Terminal Detection on Unix Systems
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build !windows
+
+package GoLog
+
+import "os"
+
+// prepareTerminal reports whether the file is a character device, terminals display ANSI escape sequences natively
+func prepareTerminal(terminalFile *os.File) bool {
+	fileInformation, statError := terminalFile.Stat()
+
+	return statError == nil && fileInformation.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,37 @@
+// Terminal Detection on Windows
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+//go:build windows
+
+package GoLog
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// prepareTerminal reports whether the file is a console displaying ANSI escape sequences
+// Virtual terminal processing is enabled on the console so cmd.exe and older PowerShell versions
+// interpret the colors, consoles refusing it are reported as unable to display colors
+// so raw escape sequences are never printed
+func prepareTerminal(terminalFile *os.File) bool {
+	consoleHandle := windows.Handle(terminalFile.Fd())
+
+	var consoleMode uint32
+
+	if windows.GetConsoleMode(consoleHandle, &consoleMode) != nil {
+		return false
+	}
+
+	if consoleMode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+
+	return windows.SetConsoleMode(consoleHandle, consoleMode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
@@ -16,13 +16,24 @@ var logInstance *GoLog.LogInstance
 
 func main() {
 	var fileDestination = "Test/_log.log"
-	logInstance = GoLog.Initialize(fileDestination)
+	logInstance = GoLog.Initialize(fileDestination, GoLog.WithoutExit())
 
 	TestLog()
-
-	// Ignore ..
-	//
-	// TestFatal()
-
+	TestError()
+	TestFatal()
+	TestPanic()
 	TestWarning()
+	TestDebug()
+	TestTrace()
+	TestCustom()
+	TestPrintf()
+	TestLeveled()
+	TestField()
+	TestWith()
+	TestDefault()
+	TestGolden()
+
+	if closeError := logInstance.Close(); closeError != nil {
+		logInstance.Error(nil, "Unable to close the log file because ", closeError)
+	}
 }
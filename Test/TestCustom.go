@@ -0,0 +1,37 @@
+// Custom Level Message Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+import (
+	GoLog "github.com/Tvative/Package-Go-Log"
+)
+
+func TestCustom() {
+	jsonString := map[string]interface{}{
+		"key_01": "a",
+		"key_02": 1,
+		"key_03": "B",
+	}
+
+	messageAudit, registerError := GoLog.RegisterLevel("audit", "AUDT", GoLog.ColorCyan, GoLog.LevelWarning)
+
+	if registerError != nil {
+		logInstance.Error(nil, "Unable to register the audit level because ", registerError)
+		return
+	}
+
+	logInstance.Custom(messageAudit, nil, "Sample audit log message 01")
+	logInstance.Custom(messageAudit, jsonString, "Sample audit log message 02")
+
+	logInstance.CustomC(messageAudit, nil, "Sample audit log message 01")
+	logInstance.CustomC(messageAudit, jsonString, "Sample audit log message 02")
+
+	logInstance.FCustom(messageAudit, nil, "Sample audit log message 01")
+	logInstance.FCustom(messageAudit, jsonString, "Sample audit log message 02")
+}
@@ -0,0 +1,32 @@
+// Debug Message Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+func TestDebug() {
+	jsonString := map[string]interface{}{
+		"key_01": "a",
+		"key_02": 1,
+		"key_03": "B",
+	}
+
+	logInstance.Debug(nil, "Sample debug log message 01")
+	logInstance.Debug(jsonString, "Sample debug log message 02")
+	logInstance.Debug(nil, "Sample debug log message 03")
+	logInstance.Debug(jsonString, "Sample debug log message 04")
+
+	logInstance.DebugC(nil, "Sample debug log message 01")
+	logInstance.DebugC(jsonString, "Sample debug log message 02")
+	logInstance.DebugC(nil, "Sample debug log message 03")
+	logInstance.DebugC(jsonString, "Sample debug log message 04")
+
+	logInstance.FDebug(nil, "Sample debug log message 01")
+	logInstance.FDebug(jsonString, "Sample debug log message 02")
+	logInstance.FDebug(nil, "Sample debug log message 03")
+	logInstance.FDebug(jsonString, "Sample debug log message 04")
+}
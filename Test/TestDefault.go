@@ -0,0 +1,21 @@
+// Default Log Instance Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+import (
+	GoLog "github.com/Tvative/Package-Go-Log"
+)
+
+func TestDefault() {
+	GoLog.Info(nil, "Sample default log message 01")
+
+	GoLog.SetDefault(logInstance)
+	GoLog.Warn(nil, "Sample default log message 02", GoLog.Int("key_01", 1))
+	GoLog.SetDefault(nil)
+}
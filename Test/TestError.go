@@ -0,0 +1,32 @@
+// Error Message Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+func TestError() {
+	jsonString := map[string]interface{}{
+		"key_01": "a",
+		"key_02": 1,
+		"key_03": "B",
+	}
+
+	logInstance.Error(nil, "Sample error log message 01")
+	logInstance.Error(jsonString, "Sample error log message 02")
+	logInstance.Error(nil, "Sample error log message 03")
+	logInstance.Error(jsonString, "Sample error log message 04")
+
+	logInstance.ErrorC(nil, "Sample error log message 01")
+	logInstance.ErrorC(jsonString, "Sample error log message 02")
+	logInstance.ErrorC(nil, "Sample error log message 03")
+	logInstance.ErrorC(jsonString, "Sample error log message 04")
+
+	logInstance.FError(nil, "Sample error log message 01")
+	logInstance.FError(jsonString, "Sample error log message 02")
+	logInstance.FError(nil, "Sample error log message 03")
+	logInstance.FError(jsonString, "Sample error log message 04")
+}
@@ -15,6 +15,10 @@ func TestFatal() {
 		"key_03": "B",
 	}
 
+	logInstance.OnFatal(func() {
+		logInstance.Flush()
+	})
+
 	logInstance.Fatal(nil, "Sample fatal log message 01")
 	logInstance.Fatal(jsonString, "Sample fatal log message 02")
 	logInstance.Fatal(nil, "Sample fatal log message 03")
@@ -0,0 +1,34 @@
+// Typed Field Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+import (
+	"errors"
+	"time"
+
+	GoLog "github.com/Tvative/Package-Go-Log"
+)
+
+func TestField() {
+	logInstance.Info(nil, "Sample typed field log message 01",
+		GoLog.String("key_01", "a"),
+		GoLog.Int("key_02", 1),
+		GoLog.Duration("key_03", 1500*time.Millisecond))
+
+	logInstance.Error(nil, "Sample typed field log message 02",
+		GoLog.Err(errors.New("sample error")),
+		GoLog.Bool("key_04", true))
+}
+
+func TestWith() {
+	childInstance := logInstance.With(map[string]interface{}{"component": "manual"}, GoLog.Int("request_id", 1))
+
+	childInstance.Info(nil, "Sample child log message 01")
+	childInstance.With(nil, GoLog.String("tenant", "a")).Warn(nil, "Sample child log message 02", GoLog.Bool("key_01", true))
+}
@@ -0,0 +1,154 @@
+// Golden Format Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	GoLog "github.com/Tvative/Package-Go-Log"
+)
+
+// goldenEntries are encoded with every built-in format and compared with the golden files
+var goldenEntries = []GoLog.Entry{
+	{
+		Time:    time.Date(2023, time.January, 2, 3, 4, 5, 600000000, time.UTC),
+		Level:   GoLog.LevelNormal,
+		Message: "Sample golden log message 01",
+	},
+	{
+		Time:    time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Level:   GoLog.LevelWarning,
+		Message: "Sample golden log message 02",
+		Fields: []GoLog.Field{
+			GoLog.String("key_01", "a b"),
+			GoLog.Int("key_02", 1),
+			GoLog.Float64("key_03", 1.5),
+			GoLog.Bool("key_04", true),
+			GoLog.Duration("key_05", 1500*time.Millisecond),
+			GoLog.String("level", "reserved"),
+		},
+	},
+	{
+		Time:    time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Level:   GoLog.LevelError,
+		Message: "Sample golden log message 03 <\"quoted\">",
+		Fields:  []GoLog.Field{GoLog.Err(errors.New("sample error"))},
+		Caller:  &GoLog.Caller{File: "/source/service/handler.go", Line: 42, Function: "service.Handle"},
+	},
+}
+
+// goldenFiles are the golden files of the built-in formats
+var goldenFiles = map[string]GoLog.Format{
+	"Test/Golden/Entry.json":   GoLog.FormatJSON,
+	"Test/Golden/Entry.logfmt": GoLog.FormatLogfmt,
+	"Test/Golden/Entry.text":   GoLog.FormatText,
+}
+
+// goldenReconfigured is the golden file of the messages logged through a reconfigured log instance
+const goldenReconfigured = "Test/Golden/Reconfigured.text"
+
+func TestGolden() {
+	updateGolden := os.Getenv("UPDATE_GOLDEN") != ""
+	goldenFailed := false
+
+	for goldenPath, goldenFormat := range goldenFiles {
+		var encodedOutput bytes.Buffer
+
+		for _, goldenEntry := range goldenEntries {
+			encodedLine, _ := goldenFormat.Format(goldenEntry)
+			encodedOutput.Write(append(encodedLine, '\n'))
+		}
+
+		goldenFailed = !compareGolden(goldenPath, encodedOutput.Bytes(), updateGolden) || goldenFailed
+	}
+
+	goldenFailed = !compareGolden(goldenReconfigured, reconfiguredOutput(), updateGolden) || goldenFailed
+
+	if goldenFailed {
+		fmt.Fprintln(os.Stderr, "Golden format check failed, run with UPDATE_GOLDEN=1 to accept deliberate format changes")
+		os.Exit(1)
+	}
+}
+
+// reconfiguredOutput returns the text lines of a log instance whose configured fields were removed by a reconfiguration
+func reconfiguredOutput() []byte {
+	var encodedOutput bytes.Buffer
+
+	terminalOutput := false
+	goldenClock := func() time.Time {
+		return time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	goldenInstance := GoLog.InitializeWriter(nil, GoLog.WithFile(false), GoLog.WithTerminal(false), GoLog.WithClock(goldenClock),
+		GoLog.WithDestination(GoLog.NewWriterDestination(&encodedOutput, GoLog.FormatText, GoLog.LevelTrace)))
+
+	goldenInstance.Reconfigure(GoLog.Config{Terminal: &terminalOutput, UTC: true, Service: "golden"})
+	goldenInstance.FLog(nil, "Sample reconfigured log message 01")
+
+	goldenInstance.Reconfigure(GoLog.Config{Terminal: &terminalOutput, UTC: true})
+	goldenInstance.FLog(nil, "Sample reconfigured log message 02")
+
+	return encodedOutput.Bytes()
+}
+
+// compareGolden compares the encoded output with the golden file, or writes the golden file when asked to
+// It reports whether the check passed
+func compareGolden(goldenPath string, encodedOutput []byte, updateGolden bool) bool {
+	if updateGolden {
+		if writeError := os.WriteFile(goldenPath, encodedOutput, 0644); writeError != nil {
+			fmt.Fprintln(os.Stderr, "Unable to write the golden file because", writeError)
+			return false
+		}
+
+		return true
+	}
+
+	goldenContent, readError := os.ReadFile(goldenPath)
+
+	if readError != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read the golden file because", readError)
+		return false
+	}
+
+	if !bytes.Equal(goldenContent, encodedOutput) {
+		fmt.Fprintln(os.Stderr, "Encoded output differs from the golden file", goldenPath)
+		printDiff(string(goldenContent), string(encodedOutput))
+
+		return false
+	}
+
+	return true
+}
+
+// printDiff writes the lines of the golden file and the encoded output that differ
+func printDiff(goldenContent string, encodedContent string) {
+	goldenLines := strings.Split(goldenContent, "\n")
+	encodedLines := strings.Split(encodedContent, "\n")
+
+	for lineIndex := 0; lineIndex < len(goldenLines) || lineIndex < len(encodedLines); lineIndex++ {
+		var goldenLine, encodedLine string
+
+		if lineIndex < len(goldenLines) {
+			goldenLine = goldenLines[lineIndex]
+		}
+
+		if lineIndex < len(encodedLines) {
+			encodedLine = encodedLines[lineIndex]
+		}
+
+		if goldenLine != encodedLine {
+			fmt.Fprintf(os.Stderr, "line %d\n- %s\n+ %s\n", lineIndex+1, goldenLine, encodedLine)
+		}
+	}
+}
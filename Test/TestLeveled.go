@@ -0,0 +1,28 @@
+// Leveled Message Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+func TestLeveled() {
+	jsonString := map[string]interface{}{
+		"key_01": "a",
+		"key_02": 1,
+		"key_03": "B",
+	}
+
+	logInstance.SetFileOutput(true)
+	logInstance.SetColorOutput(true)
+
+	logInstance.Info(nil, "Sample leveled log message 01")
+	logInstance.Warn(jsonString, "Sample leveled warning log message 02")
+	logInstance.Error(nil, "Sample leveled error log message 03")
+	logInstance.Debug(jsonString, "Sample leveled debug log message 04")
+
+	logInstance.SetFileOutput(false)
+	logInstance.SetColorOutput(false)
+}
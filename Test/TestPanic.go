@@ -0,0 +1,25 @@
+// Panic Message Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+import (
+	GoLog "github.com/Tvative/Package-Go-Log"
+)
+
+func TestPanic() {
+	jsonString := map[string]interface{}{
+		"key_01": "a",
+		"key_02": 1,
+		"key_03": "B",
+	}
+
+	defer logInstance.RecoverAndLog(GoLog.MessageError, false)
+
+	logInstance.Panic(jsonString, "Sample panic log message 01")
+}
@@ -0,0 +1,23 @@
+// Formatted Message Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+func TestPrintf() {
+	jsonString := map[string]interface{}{
+		"key_01": "a",
+		"key_02": 1,
+		"key_03": "B",
+	}
+
+	logInstance.Infof(nil, "Sample formatted log message %02d", 1)
+	logInstance.Warnf(jsonString, "Sample formatted warning log message %02d", 2)
+	logInstance.Errorf(nil, "Sample formatted error log message %02d", 3)
+	logInstance.Debugf(jsonString, "Sample formatted debug log message %02d", 4)
+	logInstance.Tracef(nil, "Sample formatted trace log message %02d", 5)
+}
@@ -0,0 +1,32 @@
+// Trace Message Manual Test
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package main
+
+func TestTrace() {
+	jsonString := map[string]interface{}{
+		"key_01": "a",
+		"key_02": 1,
+		"key_03": "B",
+	}
+
+	logInstance.Trace(nil, "Sample trace log message 01")
+	logInstance.Trace(jsonString, "Sample trace log message 02")
+	logInstance.Trace(nil, "Sample trace log message 03")
+	logInstance.Trace(jsonString, "Sample trace log message 04")
+
+	logInstance.TraceC(nil, "Sample trace log message 01")
+	logInstance.TraceC(jsonString, "Sample trace log message 02")
+	logInstance.TraceC(nil, "Sample trace log message 03")
+	logInstance.TraceC(jsonString, "Sample trace log message 04")
+
+	logInstance.FTrace(nil, "Sample trace log message 01")
+	logInstance.FTrace(jsonString, "Sample trace log message 02")
+	logInstance.FTrace(nil, "Sample trace log message 03")
+	logInstance.FTrace(jsonString, "Sample trace log message 04")
+}
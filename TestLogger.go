@@ -0,0 +1,113 @@
+// Test Output Logger
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// TestingT is the part of testing.TB a test logger writes through, so *testing.T, *testing.B and *testing.F can be passed
+type TestingT interface {
+	Helper()                                                    // Helper marks the calling function as a test helper
+	Logf(messageFormat string, messageArguments ...interface{}) // Logf records the formatted text in the test output
+}
+
+// testOutput is implemented by testing.TB from Go 1.25 on, writing to the test output without a source location
+type testOutput interface {
+	Output() io.Writer // Output returns the writer of the test output
+}
+
+// testCleanup is implemented by testing.TB, running the function once the test and its subtests completed
+type testCleanup interface {
+	Cleanup(cleanupFunction func()) // Cleanup registers the function to run when the test completes
+}
+
+// testDestination writes log messages to the output of a test
+type testDestination struct {
+	testingT     TestingT   // testingT is the test receiving the messages
+	testLock     sync.Mutex // testLock guards the completion of the test
+	testComplete bool       // testComplete reports that the test completed and messages are dropped
+}
+
+// NewTestLogger returns a log instance writing every message to the output of the test through testingT,
+// so messages appear along with the rest of the test output and follow its rules, shown for failed tests
+// or with the -v flag only
+// Every message starts with the file and line of the log call, as the messages of t.Logf do
+// With Go 1.25 and later the messages are written to t.Output, otherwise through t.Logf, where the
+// location the testing package adds refers to this package
+// The file and terminal output are disabled, the options are applied afterwards and may enable them again
+// Messages logged once the test completed are dropped instead of failing the test binary
+func NewTestLogger(testingT TestingT, logOptions ...Option) *LogInstance {
+	logDestination := &testDestination{testingT: testingT}
+
+	if cleanupTest, hasCleanup := testingT.(testCleanup); hasCleanup {
+		cleanupTest.Cleanup(logDestination.completeTest)
+	}
+
+	testOptions := []Option{WithFile(false), WithTerminal(false), WithCaller(0), WithDestination(logDestination)}
+
+	return InitializeWriter(nil, append(testOptions, logOptions...)...)
+}
+
+// WriteEntry writes the log message to the test output, prefixed with the location of the log call
+func (logDestination *testDestination) WriteEntry(logEntry Entry) error {
+	return logDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry writes the log message to the test output, prefixed with the location of the log call
+func (logDestination *testDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	logDestination.testLock.Lock()
+	defer logDestination.testLock.Unlock()
+
+	if logDestination.testComplete {
+		return nil
+	}
+
+	lineBuffer := acquireBuffer()
+	defer releaseBuffer(lineBuffer)
+
+	if messageEntry.entryCaller != nil {
+		*lineBuffer = append(*lineBuffer, filepath.Base(messageEntry.entryCaller.callerFile)...)
+		*lineBuffer = append(*lineBuffer, ':')
+		*lineBuffer = strconv.AppendInt(*lineBuffer, int64(messageEntry.entryCaller.callerLine), 10)
+		*lineBuffer = append(*lineBuffer, ':')
+	}
+
+	// The location is already written, so the caller is left out of the message text
+
+	messageEntry.entryCaller = nil
+	*lineBuffer = appendTextLine(logInstance, *lineBuffer, messageEntry)
+
+	if outputTest, hasOutput := logDestination.testingT.(testOutput); hasOutput {
+		_, writeError := outputTest.Output().Write(append(*lineBuffer, '\n'))
+		return writeError
+	}
+
+	logDestination.testingT.Helper()
+	logDestination.testingT.Logf("%s", *lineBuffer)
+
+	return nil
+}
+
+// completeTest drops the messages logged once the test completed
+func (logDestination *testDestination) completeTest() {
+	logDestination.testLock.Lock()
+	defer logDestination.testLock.Unlock()
+
+	logDestination.testComplete = true
+}
+
+// Close stops writing to the test output
+func (logDestination *testDestination) Close() error {
+	logDestination.completeTest()
+	return nil
+}
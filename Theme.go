@@ -0,0 +1,80 @@
+// Terminal Color Themes
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Theme colors the parts of colored text terminal messages independently
+// The level tag, such as [ WARN ], keeps the color of its level and empty colors leave a part uncolored
+type Theme struct {
+	TimeColor     string // TimeColor colors the message time
+	CallerColor   string // CallerColor colors the caller
+	MessageColor  string // MessageColor colors the message content
+	FieldKeyColor string // FieldKeyColor colors the keys of the fields
+	StackColor    string // StackColor colors the stack trace
+}
+
+// WithTheme colors the parts of colored text terminal messages with the theme instead of the whole line
+// For example WithTheme(Theme{TimeColor: ColorDim, FieldKeyColor: ColorCyan}) dims the time and colors the keys
+// Other formats keep coloring the whole line
+func WithTheme(colorTheme Theme) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.logTheme = &colorTheme
+	}
+}
+
+// WithLevelTagColor colors only the level tag of colored text terminal messages, such as [ WARN ]
+func WithLevelTagColor() Option {
+	return WithTheme(Theme{})
+}
+
+// themedText returns the colored text terminal line of the log message with the newline
+func (colorTheme *Theme) themedText(generatedTime string, levelLabel string, messageEntry logEntry) string {
+	var lineBuilder strings.Builder
+
+	lineBuilder.WriteString(themedPart(colorTheme.TimeColor, generatedTime))
+	lineBuilder.WriteString(themedPart(messageEntry.messageLevel.levelColor, levelLabel))
+
+	if messageEntry.entryCaller != nil {
+		lineBuilder.WriteString(themedPart(colorTheme.CallerColor,
+			messageEntry.entryCaller.shortFile()+" "+messageEntry.entryCaller.callerFunction) + " ")
+	}
+
+	lineBuilder.WriteString(themedPart(colorTheme.MessageColor, fmt.Sprint(messageEntry.messageParts...)))
+
+	if messageEntry.entryFields != nil {
+		lineBuilder.WriteString(" [")
+
+		for _, entryField := range messageEntry.entryFields {
+			lineBuilder.WriteString(" (" + themedPart(colorTheme.FieldKeyColor, entryField.Key) + ": " + fieldText(entryField) + ")")
+		}
+
+		lineBuilder.WriteString(" ]")
+	}
+
+	if messageEntry.entryStack != "" {
+		lineBuilder.WriteString("\n" + themedPart(colorTheme.StackColor, messageEntry.entryStack))
+	}
+
+	lineBuilder.WriteByte('\n')
+
+	return lineBuilder.String()
+}
+
+// themedPart wraps the text in the color code unless the color is empty or the default color
+func themedPart(colorCode string, partText string) string {
+	if colorCode == "" || colorCode == ColorDefault || partText == "" {
+		return partText
+	}
+
+	return degradeColor(colorCode) + partText + ColorDefault
+}
@@ -0,0 +1,125 @@
+// Timestamp Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	TimeFormatDefault     string = ""               // TimeFormatDefault represents the long time followed by the milliseconds and nanoseconds
+	TimeFormatRFC3339     string = time.RFC3339     // TimeFormatRFC3339 represents the RFC 3339 layout with seconds
+	TimeFormatRFC3339Nano string = time.RFC3339Nano // TimeFormatRFC3339Nano represents the RFC 3339 layout with nanoseconds
+	TimeFormatDateTime    string = time.DateTime    // TimeFormatDateTime represents the date and time without a zone
+	TimeFormatKitchen     string = time.Kitchen     // TimeFormatKitchen represents the hour and minute in the 12 hour clock
+)
+
+// TimePrecision represents the fractional second precision of the default time format
+type TimePrecision int
+
+const (
+	PrecisionLegacy TimePrecision = iota // PrecisionLegacy represents the milliseconds and nanoseconds separated by colons
+	PrecisionSecond                      // PrecisionSecond represents whole seconds without a fractional part
+	PrecisionMilli                       // PrecisionMilli represents a three digit fractional part
+	PrecisionMicro                       // PrecisionMicro represents a six digit fractional part
+	PrecisionNano                        // PrecisionNano represents a nine digit fractional part
+)
+
+// precisionLayouts maps every precision to the layout of the default time format
+var precisionLayouts = map[TimePrecision]string{
+	PrecisionSecond: "2006-01-02 15:04:05",
+	PrecisionMilli:  "2006-01-02 15:04:05.000",
+	PrecisionMicro:  "2006-01-02 15:04:05.000000",
+	PrecisionNano:   "2006-01-02 15:04:05.000000000",
+}
+
+// WithTimePrecision sets the fractional second precision of the default time format
+// The fraction is written as a single zero padded component after a dot
+// A time format set with WithTimeFormat carries its own precision and is not affected
+func WithTimePrecision(timePrecision TimePrecision) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.timePrecision = timePrecision
+	}
+}
+
+// WithUnixTime writes the time of every log message as an integer count since the Unix epoch
+// The unit selects the resolution, for example time.Second or time.Millisecond
+func WithUnixTime(unixUnit time.Duration) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.unixUnit = unixUnit
+	}
+}
+
+// WithClock replaces time.Now as the source of the message time
+// A fixed clock makes the log output reproducible, which is useful in tests
+// The clock is also used to schedule rotation and to apply the retention limits
+// A nil clock keeps time.Now
+func WithClock(logClock func() time.Time) Option {
+	return func(logInstance *LogInstance) {
+		if logClock != nil {
+			logInstance.logClock = logClock
+		}
+	}
+}
+
+// WithUTC formats the time of every log message in UTC instead of the local time zone
+func WithUTC() Option {
+	return func(logInstance *LogInstance) {
+		logInstance.utcTime = true
+	}
+}
+
+// generateTimestamp formats the time of a log message
+func generateTimestamp(logInstance *LogInstance, getTime time.Time) string {
+	return string(appendTimestamp(logInstance, nil, getTime))
+}
+
+// appendTimestamp appends the formatted time of a log message to the line buffer
+// Without a configured time format or precision, the long time is followed by the milliseconds and nanoseconds
+func appendTimestamp(logInstance *LogInstance, lineBuffer []byte, getTime time.Time) []byte {
+	if unixTime, isUnix := unixTimestamp(logInstance, getTime); isUnix {
+		return strconv.AppendInt(lineBuffer, unixTime, 10)
+	}
+
+	getTime = localTime(logInstance, getTime)
+
+	if logInstance.timeFormat != "" {
+		return getTime.AppendFormat(lineBuffer, logInstance.timeFormat)
+	}
+
+	if precisionLayout, layoutExists := precisionLayouts[logInstance.timePrecision]; layoutExists {
+		return getTime.AppendFormat(lineBuffer, precisionLayout)
+	}
+
+	lineBuffer = getTime.AppendFormat(lineBuffer, "2006-01-02 15:04:05")
+	lineBuffer = strconv.AppendInt(append(lineBuffer, ':'), int64(getTime.Nanosecond()/1e6), 10)
+	lineBuffer = strconv.AppendInt(append(lineBuffer, ':'), int64(getTime.Nanosecond()), 10)
+
+	return lineBuffer
+}
+
+// localTime converts the time of a log message to the configured time zone
+func localTime(logInstance *LogInstance, getTime time.Time) time.Time {
+	if logInstance.utcTime {
+		return getTime.UTC()
+	}
+
+	return getTime
+}
+
+// unixTimestamp returns the time of a log message since the Unix epoch in the configured unit
+// It reports false when the time is not written as a Unix timestamp
+func unixTimestamp(logInstance *LogInstance, getTime time.Time) (int64, bool) {
+	if logInstance.unixUnit <= 0 {
+		return 0, false
+	}
+
+	return getTime.UnixNano() / int64(logInstance.unixUnit), true
+}
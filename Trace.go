@@ -0,0 +1,24 @@
+// Trace Message Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// Trace logs a message to the configured destinations with trace formatting
+func (logInstance *LogInstance) Trace(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageTrace, jsonContent, messageContent...)
+}
+
+// TraceC logs a message to the terminal with trace formatting
+func (logInstance *LogInstance) TraceC(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, false, true, true, MessageTrace, jsonContent, messageContent...)
+}
+
+// FTrace logs a trace message to the log file
+func (logInstance *LogInstance) FTrace(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printOutPut(logInstance, true, false, false, MessageTrace, jsonContent, messageContent...)
+}
@@ -22,3 +22,8 @@ func (logInstance *LogInstance) WarningC(jsonContent map[string]interface{}, mes
 func (logInstance *LogInstance) FWarning(jsonContent map[string]interface{}, messageContent ...interface{}) {
 	printOutPut(logInstance, true, false, false, MessageWarning, jsonContent, messageContent...)
 }
+
+// Warn logs a message to the configured destinations with warning formatting
+func (logInstance *LogInstance) Warn(jsonContent map[string]interface{}, messageContent ...interface{}) {
+	printConfigured(logInstance, MessageWarning, jsonContent, messageContent...)
+}
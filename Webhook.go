@@ -0,0 +1,147 @@
+// Chat Webhook Alert Hook
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookStyle selects the payload posted by a WebhookHook
+type WebhookStyle int
+
+const (
+	WebhookJSON    WebhookStyle = iota // WebhookJSON posts the message in the JSON format of the log instance
+	WebhookSlack                       // WebhookSlack posts a Slack incoming webhook payload with the formatted message as its text
+	WebhookDiscord                     // WebhookDiscord posts a Discord webhook payload with the formatted message as its content
+)
+
+// discordContentLimit is the maximum length of the content of a Discord webhook message
+const discordContentLimit int = 2000
+
+// WebhookHook posts alerts for severe log messages to a chat or HTTP webhook
+// Alerts are delivered from the background and limited so an error storm does not flood the channel
+type WebhookHook struct {
+	webhookURL     string       // webhookURL is the address receiving the alerts
+	webhookStyle   WebhookStyle // webhookStyle selects the posted payload
+	minimumLevel   Level        // minimumLevel is the minimum severity a message needs to raise an alert
+	alertFormatter Formatter    // alertFormatter encodes the message of chat payloads
+	httpSettings   HTTPSettings // httpSettings configures the requests
+
+	limitLock  sync.Mutex   // limitLock guards the alert limit across log instances sharing the hook
+	alertLimit *rateLimiter // alertLimit limits the number of alerts per window
+	logBatch   *batchSender // logBatch collects and delivers the encoded alerts
+}
+
+// NewWebhookHook returns a hook posting messages at or above minimumLevel to webhookURL in the webhook style
+// At most alertLimit alerts are posted every alertWindow, the next alert after dropped ones
+// carries their number in the suppressed field, a zero alertWindow disables the limit
+// Chat payloads hold the message encoded with alertFormatter, or in the text format when it is nil
+// Register it with AddHook so that Flush and Close on the log instance post the alerts still waiting in the batch
+func NewWebhookHook(webhookURL string, webhookStyle WebhookStyle, minimumLevel Level, alertFormatter Formatter,
+	alertLimit int, alertWindow time.Duration, httpSettings HTTPSettings) *WebhookHook {
+	if alertFormatter == nil {
+		alertFormatter = FormatText
+	}
+
+	// Deliver every alert on its own so a retry never posts an alert twice
+
+	httpSettings.BatchSize = 1
+
+	webhookHook := &WebhookHook{
+		webhookURL:     webhookURL,
+		webhookStyle:   webhookStyle,
+		minimumLevel:   minimumLevel,
+		alertFormatter: alertFormatter,
+		httpSettings:   httpSettings,
+	}
+
+	if alertWindow > 0 {
+		webhookHook.alertLimit = &rateLimiter{messageLimit: alertLimit, limitWindow: alertWindow}
+	}
+
+	webhookHook.logBatch = newBatchSender(httpSettings.BatchSettings, webhookHook.deliver)
+
+	return webhookHook
+}
+
+// Fire queues an alert for the entry when it is severe enough and within the limit, and never drops the entry
+func (webhookHook *WebhookHook) Fire(hookEntry *Entry) bool {
+	if hookEntry.Level < webhookHook.minimumLevel {
+		return true
+	}
+
+	alertEntry := hookEntry.internalEntry()
+
+	// Limit even fatal and panic alerts so a crash loop cannot flood the channel
+
+	if webhookHook.alertLimit != nil {
+		webhookHook.limitLock.Lock()
+		admittedAlert := webhookHook.alertLimit.allow(&alertEntry)
+		webhookHook.limitLock.Unlock()
+
+		if !admittedAlert {
+			return true
+		}
+	}
+
+	logInstance := hookEntry.instance()
+
+	webhookHook.logBatch.add(logInstance, webhookHook.encodePayload(logInstance, alertEntry))
+
+	// Deliver right away since the process ends after a fatal message or a panic
+
+	if hookEntry.Level >= LevelPanic {
+		webhookHook.logBatch.deliverPending()
+	}
+
+	return true
+}
+
+// Flush delivers every pending alert
+func (webhookHook *WebhookHook) Flush() error {
+	return webhookHook.logBatch.Flush()
+}
+
+// Close delivers every pending alert and stops the background delivery
+func (webhookHook *WebhookHook) Close() error {
+	return webhookHook.logBatch.Close()
+}
+
+// deliver posts the payload of the batch
+func (webhookHook *WebhookHook) deliver(batchItems []interface{}) error {
+	_, postError := postBatch(webhookHook.webhookURL, "application/json",
+		[]byte(batchItems[0].(string)), webhookHook.httpSettings)
+
+	return postError
+}
+
+// encodePayload encodes the alert in the webhook style
+func (webhookHook *WebhookHook) encodePayload(logInstance *LogInstance, alertEntry logEntry) string {
+	if webhookHook.webhookStyle == WebhookJSON {
+		return encodeJSON(jsonTime(logInstance, alertEntry.entryTime), alertEntry)
+	}
+
+	alertText := strings.TrimRight(encodeEntry(logInstance, webhookHook.alertFormatter, alertEntry), "\n")
+	payloadKey := "text"
+
+	if webhookHook.webhookStyle == WebhookDiscord {
+		payloadKey = "content"
+
+		if len(alertText) > discordContentLimit {
+			alertText = strings.ToValidUTF8(alertText[:discordContentLimit-3], "") + "..."
+		}
+	}
+
+	encodedPayload, _ := json.Marshal(map[string]string{payloadKey: alertText})
+
+	return string(encodedPayload)
+}
@@ -0,0 +1,22 @@
+// Child Logger Generation
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+// With returns a child logger that attaches the selected fields to every log message
+// The child logger copies the settings of its parent and shares its level and destinations
+func (logInstance *LogInstance) With(jsonContent map[string]interface{}, boundFields ...Field) *LogInstance {
+	logInstance.logLock.Lock()
+	defer logInstance.logLock.Unlock()
+
+	childInstance := *logInstance
+	childFields, _ := collectFields(logInstance.boundFields, jsonContent, nil)
+	childInstance.boundFields = append(childFields, boundFields...)
+
+	return &childInstance
+}
@@ -0,0 +1,41 @@
+// Write Error Handling
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "io"
+
+// WithErrorHandler calls errorHandler whenever writing a message to the file, the network
+// or an additional destination fails, with the error and the message that was not written
+// The handler runs while the log instance lock is held, so it must not log through the same log instance
+func WithErrorHandler(errorHandler func(writeError error, failedEntry Entry)) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.errorHandler = errorHandler
+	}
+}
+
+// WithFallback writes messages that could not be written to the file, the network
+// or an additional destination to fallbackWriter instead, for example WithFallback(os.Stderr)
+// The messages are written as they were encoded for the failed output, in the text format for destinations
+func WithFallback(fallbackWriter io.Writer) Option {
+	return func(logInstance *LogInstance) {
+		logInstance.fallbackWriter = fallbackWriter
+	}
+}
+
+// reportWriteError hands the failed write to the error handler and writes the encoded line to the fallback writer
+// The log instance lock must be held by the caller
+func reportWriteError(logInstance *LogInstance, writeError error, messageEntry logEntry, encodedLine string) {
+	if logInstance.errorHandler != nil {
+		logInstance.errorHandler(writeError, exportEntry(logInstance, messageEntry))
+	}
+
+	if logInstance.fallbackWriter != nil {
+		io.WriteString(logInstance.fallbackWriter, encodedLine+"\n")
+	}
+}
@@ -0,0 +1,72 @@
+// Writer Output Destination
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package GoLog
+
+import "io"
+
+// WriterDestination writes log messages to a writer with its own format and level
+// Attaching several writer destinations fans every message out to files, streams and network connections alike
+type WriterDestination struct {
+	logWriter    io.Writer // logWriter receives the encoded messages
+	logFormatter Formatter // logFormatter encodes the messages
+	minimumLevel Level     // minimumLevel is the minimum severity a message needs to be written
+}
+
+// NewWriterDestination returns a destination writing log messages at or above minimumLevel to logWriter
+// The messages are encoded with logFormatter, either a built-in format such as FormatJSON or a custom formatter
+// Standard streams are never closed, other writers are closed along with the log instance when they support it
+func NewWriterDestination(logWriter io.Writer, logFormatter Formatter, minimumLevel Level) *WriterDestination {
+	return &WriterDestination{logWriter: logWriter, logFormatter: logFormatter, minimumLevel: minimumLevel}
+}
+
+// NewFileDestination opens the file at logPath in append mode and returns a destination writing to it
+func NewFileDestination(logPath string, logFormatter Formatter, minimumLevel Level) (*WriterDestination, error) {
+	fileDescriptor, openError := openFile(logPath, true)
+
+	if openError != nil {
+		return nil, openError
+	}
+
+	return NewWriterDestination(fileDescriptor, logFormatter, minimumLevel), nil
+}
+
+// WriteEntry encodes the log message and writes it as a single line
+func (writerDestination *WriterDestination) WriteEntry(logEntry Entry) error {
+	return writerDestination.writeEntry(logEntry.instance(), logEntry.internalEntry())
+}
+
+// writeEntry encodes the log message and writes it as a single line
+func (writerDestination *WriterDestination) writeEntry(logInstance *LogInstance, messageEntry logEntry) error {
+	if messageEntry.messageLevel.levelSeverity < writerDestination.minimumLevel {
+		return nil
+	}
+
+	_, writeError := io.WriteString(writerDestination.logWriter,
+		encodeEntry(logInstance, writerDestination.logFormatter, messageEntry)+"\n")
+
+	return writeError
+}
+
+// Flush flushes and syncs the writer when it supports it
+func (writerDestination *WriterDestination) Flush() error {
+	return flushOutput(writerDestination.logWriter)
+}
+
+// Close flushes the writer and closes it unless it is a standard stream
+func (writerDestination *WriterDestination) Close() error {
+	flushError := flushOutput(writerDestination.logWriter)
+
+	if logCloser, isCloser := writerDestination.logWriter.(io.Closer); isCloser && !isStandardStream(writerDestination.logWriter) {
+		if closeError := logCloser.Close(); flushError == nil {
+			flushError = closeError
+		}
+	}
+
+	return flushError
+}
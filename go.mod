@@ -1,3 +1,13 @@
 module github.com/Tvative/Package-Go-Log
 
 go 1.21
+
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/go-logr/logr v1.4.2
+	go.opentelemetry.io/otel/trace v1.28.0
+	golang.org/x/sys v0.20.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require go.opentelemetry.io/otel v1.28.0 // indirect
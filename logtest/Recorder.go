@@ -0,0 +1,297 @@
+// Log Test Recorder
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+// Package logtest records the messages of a log instance in memory so applications can test their logging
+package logtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	GoLog "github.com/Tvative/Package-Go-Log"
+)
+
+const (
+	keyTime     string = "time"     // keyTime is the key holding the message time
+	keyLevel    string = "level"    // keyLevel is the key holding the level name
+	keyMessage  string = "message"  // keyMessage is the key holding the message content
+	keyCaller   string = "caller"   // keyCaller is the key holding the source file and line
+	keyFunction string = "function" // keyFunction is the key holding the calling function
+	keyStack    string = "stack"    // keyStack is the key holding the stack trace
+	keyFields   string = "fields."  // keyFields prefixes fields colliding with the reserved keys
+)
+
+// Entry is a recorded log message, parsed back from the JSON line the log instance wrote
+type Entry struct {
+	Time      time.Time              // Time is the time the message was logged, zero when the time format is not RFC 3339
+	Level     GoLog.Level            // Level is the severity of the message
+	LevelName string                 // LevelName is the name of the level, such as info
+	Message   string                 // Message is the message content without the fields
+	Caller    string                 // Caller is the source file and line when the caller was captured
+	Function  string                 // Function is the calling function when the caller was captured
+	Stack     string                 // Stack is the stack trace when it was captured
+	Fields    map[string]interface{} // Fields are the fields of the message, integers as int64 and other numbers as float64
+}
+
+// Recorder is an in-memory sink recording every message written by a log instance as a parsed entry
+// Messages are recorded after the level, sampling, rate limits and hooks, as any other destination receives them
+// With asynchronous logging, call Flush on the log instance before reading the entries
+type Recorder struct {
+	recordLock      sync.Mutex // recordLock guards the recorded entries against concurrent log calls
+	pendingOutput   []byte     // pendingOutput holds the start of a line not yet complete
+	recordedEntries []Entry    // recordedEntries are the recorded messages in the order they were written
+}
+
+// New returns a log instance recording its messages in the returned recorder, with the file and terminal output disabled
+// The options are applied after the recording ones, so they may enable other outputs again
+func New(logOptions ...GoLog.Option) (*GoLog.LogInstance, *Recorder) {
+	logRecorder := NewRecorder()
+	recordOptions := []GoLog.Option{GoLog.WithFile(false), GoLog.WithTerminal(false), logRecorder.Option()}
+
+	return GoLog.InitializeWriter(nil, append(recordOptions, logOptions...)...), logRecorder
+}
+
+// NewRecorder returns an empty recorder, attached to a log instance with the option of Option
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Option returns the option attaching the recorder to a log instance as an additional destination
+func (logRecorder *Recorder) Option() GoLog.Option {
+	return GoLog.WithDestination(GoLog.NewWriterDestination(logRecorder, GoLog.FormatJSON, GoLog.LevelTrace))
+}
+
+// Write records every complete JSON line of the output
+// Lines that are not JSON objects are recorded with the whole line as the message
+func (logRecorder *Recorder) Write(writeContent []byte) (int, error) {
+	logRecorder.recordLock.Lock()
+	defer logRecorder.recordLock.Unlock()
+
+	logRecorder.pendingOutput = append(logRecorder.pendingOutput, writeContent...)
+
+	for {
+		lineEnd := bytes.IndexByte(logRecorder.pendingOutput, '\n')
+
+		if lineEnd < 0 {
+			break
+		}
+
+		logRecorder.recordedEntries = append(logRecorder.recordedEntries, parseEntry(logRecorder.pendingOutput[:lineEnd]))
+		logRecorder.pendingOutput = logRecorder.pendingOutput[lineEnd+1:]
+	}
+
+	return len(writeContent), nil
+}
+
+// Entries returns a copy of the recorded entries in the order they were written
+func (logRecorder *Recorder) Entries() []Entry {
+	return logRecorder.filter(func(Entry) bool {
+		return true
+	})
+}
+
+// Len returns the number of recorded entries
+func (logRecorder *Recorder) Len() int {
+	logRecorder.recordLock.Lock()
+	defer logRecorder.recordLock.Unlock()
+
+	return len(logRecorder.recordedEntries)
+}
+
+// Reset forgets every recorded entry
+func (logRecorder *Recorder) Reset() {
+	logRecorder.recordLock.Lock()
+	defer logRecorder.recordLock.Unlock()
+
+	logRecorder.recordedEntries = nil
+}
+
+// FilterLevel returns the recorded entries of the level
+func (logRecorder *Recorder) FilterLevel(logLevel GoLog.Level) []Entry {
+	return logRecorder.filter(func(recordedEntry Entry) bool {
+		return recordedEntry.Level == logLevel
+	})
+}
+
+// FilterMessage returns the recorded entries whose message contains the text
+func (logRecorder *Recorder) FilterMessage(messageText string) []Entry {
+	return logRecorder.filter(func(recordedEntry Entry) bool {
+		return strings.Contains(recordedEntry.Message, messageText)
+	})
+}
+
+// FilterField returns the recorded entries holding the field with the value
+// Integer values are compared as int64 and other numbers as float64, so both Int and Float64 fields match
+// Slices, maps and structs are compared with their JSON form, so a []string matches the recorded list of strings
+func (logRecorder *Recorder) FilterField(fieldKey string, fieldValue interface{}) []Entry {
+	expectedValue := comparableValue(fieldValue)
+
+	return logRecorder.filter(func(recordedEntry Entry) bool {
+		recordedValue, hasField := recordedEntry.Fields[fieldKey]
+		return hasField && reflect.DeepEqual(comparableValue(recordedValue), expectedValue)
+	})
+}
+
+// ContainsMessage reports whether any recorded entry has a message containing the text
+func (logRecorder *Recorder) ContainsMessage(messageText string) bool {
+	return len(logRecorder.FilterMessage(messageText)) > 0
+}
+
+// filter returns the recorded entries matching the predicate
+func (logRecorder *Recorder) filter(entryMatches func(Entry) bool) []Entry {
+	logRecorder.recordLock.Lock()
+	defer logRecorder.recordLock.Unlock()
+
+	matchingEntries := make([]Entry, 0, len(logRecorder.recordedEntries))
+
+	for _, recordedEntry := range logRecorder.recordedEntries {
+		if entryMatches(recordedEntry) {
+			matchingEntries = append(matchingEntries, recordedEntry)
+		}
+	}
+
+	return matchingEntries
+}
+
+// parseEntry parses a JSON line written by a log instance
+func parseEntry(encodedLine []byte) Entry {
+	var decodedLine map[string]interface{}
+
+	lineDecoder := json.NewDecoder(bytes.NewReader(encodedLine))
+	lineDecoder.UseNumber()
+
+	if lineDecoder.Decode(&decodedLine) != nil {
+		return Entry{Message: string(encodedLine), Fields: map[string]interface{}{}}
+	}
+
+	parsedEntry := Entry{Fields: make(map[string]interface{}, len(decodedLine))}
+
+	for lineKey, lineValue := range decodedLine {
+		textValue, _ := lineValue.(string)
+
+		switch lineKey {
+		case keyTime:
+			parsedEntry.Time, _ = time.Parse(time.RFC3339Nano, textValue)
+
+		case keyLevel:
+			parsedEntry.LevelName = textValue
+			parsedEntry.Level, _ = GoLog.ParseLevel(textValue)
+
+		case keyMessage:
+			parsedEntry.Message = textValue
+
+		case keyCaller:
+			parsedEntry.Caller = textValue
+
+		case keyFunction:
+			parsedEntry.Function = textValue
+
+		case keyStack:
+			parsedEntry.Stack = textValue
+
+		default:
+			parsedEntry.Fields[strings.TrimPrefix(lineKey, keyFields)] = numberValue(lineValue)
+		}
+	}
+
+	return parsedEntry
+}
+
+// numberValue converts the JSON numbers of a decoded value to int64 when they are integers and to float64 otherwise
+func numberValue(decodedValue interface{}) interface{} {
+	switch typedValue := decodedValue.(type) {
+	case json.Number:
+		if integerValue, parseError := typedValue.Int64(); parseError == nil {
+			return integerValue
+		}
+
+		floatValue, _ := typedValue.Float64()
+
+		return floatValue
+
+	case map[string]interface{}:
+		for mapKey, mapValue := range typedValue {
+			typedValue[mapKey] = numberValue(mapValue)
+		}
+
+	case []interface{}:
+		for sliceIndex, sliceValue := range typedValue {
+			typedValue[sliceIndex] = numberValue(sliceValue)
+		}
+	}
+
+	return decodedValue
+}
+
+// comparableValue returns the value in the form the recorded fields hold it, so both can be compared
+func comparableValue(fieldValue interface{}) interface{} {
+	switch typedValue := fieldValue.(type) {
+	case int:
+		return int64(typedValue)
+
+	case int8:
+		return int64(typedValue)
+
+	case int16:
+		return int64(typedValue)
+
+	case int32:
+		return int64(typedValue)
+
+	case uint8:
+		return int64(typedValue)
+
+	case uint16:
+		return int64(typedValue)
+
+	case uint32:
+		return int64(typedValue)
+
+	case float32:
+		return float64(typedValue)
+
+	case float64:
+		if typedValue == float64(int64(typedValue)) {
+			return int64(typedValue)
+		}
+
+	case time.Duration:
+		return typedValue.String()
+	}
+
+	switch reflect.ValueOf(fieldValue).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct, reflect.Pointer:
+		return decodedValue(fieldValue)
+	}
+
+	return fieldValue
+}
+
+// decodedValue returns the value as it is recorded once written as JSON, the value itself when it cannot be encoded
+func decodedValue(fieldValue interface{}) interface{} {
+	encodedValue, encodeError := json.Marshal(fieldValue)
+
+	if encodeError != nil {
+		return fieldValue
+	}
+
+	var recordedValue interface{}
+
+	valueDecoder := json.NewDecoder(bytes.NewReader(encodedValue))
+	valueDecoder.UseNumber()
+
+	if valueDecoder.Decode(&recordedValue) != nil {
+		return fieldValue
+	}
+
+	return numberValue(recordedValue)
+}
@@ -0,0 +1,43 @@
+// Log Test Recorder Tests
+//
+// Copyright (c) 2023 Tvative
+// All Rights Reserved
+//
+// Use of this source code is governed by
+// certain licenses found in the LICENSE file
+
+package logtest
+
+import (
+	"testing"
+
+	GoLog "github.com/Tvative/Package-Go-Log"
+)
+
+func TestFilterFieldComposite(t *testing.T) {
+	logInstance, logRecorder := New()
+
+	logInstance.FLog(nil, "tagged message", GoLog.Any("tags", []string{"api", "db"}))
+	logInstance.FLog(nil, "counted message", GoLog.Any("counts", map[string]int{"retries": 3}))
+	logInstance.FLog(nil, "plain message", GoLog.Int("tags", 2))
+
+	if taggedEntries := logRecorder.FilterField("tags", []string{"api", "db"}); len(taggedEntries) != 1 {
+		t.Errorf("filtering a slice field matched %d entries, want 1", len(taggedEntries))
+	}
+
+	if decodedEntries := logRecorder.FilterField("tags", []interface{}{"api", "db"}); len(decodedEntries) != 1 {
+		t.Errorf("filtering a decoded slice field matched %d entries, want 1", len(decodedEntries))
+	}
+
+	if countedEntries := logRecorder.FilterField("counts", map[string]int{"retries": 3}); len(countedEntries) != 1 {
+		t.Errorf("filtering a map field matched %d entries, want 1", len(countedEntries))
+	}
+
+	if plainEntries := logRecorder.FilterField("tags", 2); len(plainEntries) != 1 {
+		t.Errorf("filtering an integer field matched %d entries, want 1", len(plainEntries))
+	}
+
+	if missingEntries := logRecorder.FilterField("tags", []string{"api"}); len(missingEntries) != 0 {
+		t.Errorf("filtering a different slice matched %d entries, want none", len(missingEntries))
+	}
+}